@@ -0,0 +1,7 @@
+data "project_build_info_repository" "myproject" {
+  project_key = project.myproject.key
+}
+
+output "build_info_repo" {
+  value = data.project_build_info_repository.myproject.key
+}
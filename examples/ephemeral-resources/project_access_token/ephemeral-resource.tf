@@ -0,0 +1,11 @@
+ephemeral "project_access_token" "myproject" {
+  project_key = project.myproject.key
+  roles       = ["Developer"]
+  expires_in  = 600
+}
+
+provider "artifactory" {
+  alias        = "myproject"
+  url          = "https://myinstance.jfrog.io"
+  access_token = ephemeral.project_access_token.myproject.access_token
+}
@@ -0,0 +1 @@
+terraform import project_admin.myproject myproject
@@ -0,0 +1,6 @@
+resource "project_admin" "myproject" {
+  project_key = project.myproject.key
+
+  users  = ["jane.doe", "john.doe"]
+  groups = ["myproject-admins"]
+}
@@ -0,0 +1,11 @@
+resource "project_build_discard" "myproject" {
+  project_key      = project.myproject.key
+  max_builds       = 100
+  max_days         = 90
+  delete_artifacts = true
+
+  # Discard the builds again every month
+  triggers = {
+    month = formatdate("YYYY-MM", timestamp())
+  }
+}
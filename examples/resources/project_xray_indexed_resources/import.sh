@@ -0,0 +1 @@
+terraform import project_xray_indexed_resources.myproject myproject
@@ -0,0 +1,6 @@
+resource "project_xray_indexed_resources" "myproject" {
+  project_key = project.myproject.key
+
+  repos  = ["myproject-docker-local", "myproject-maven-local"]
+  builds = ["myproject-backend", "myproject-frontend"]
+}
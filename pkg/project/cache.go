@@ -0,0 +1,197 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cacheableUrls are the listings fetched repeatedly during a run, e.g. the project roles and environments for
+// each project_role, or the project repositories on each read. The project key is taken from the first
+// submatch, or from the project query parameter. Only writes through this provider invalidate the cache, so
+// changes made by others during the run (e.g. another provider) are not seen.
+var cacheableUrls = []*regexp.Regexp{
+	regexp.MustCompile(`^/access/api/v1/projects/([^/]+)/roles$`),
+	regexp.MustCompile(`^/access/api/v1/projects/([^/]+)/environments$`),
+	regexp.MustCompile(`^/artifactory/api/(repositories)$`),
+}
+
+const usageUrl = "/artifactory/api/system/usage"
+
+// projectWriteUrl matches the writes to a project and its members, roles, environments, ... The attach and
+// detach URLs of the repositories use `_` as project key.
+var projectWriteUrl = regexp.MustCompile(`^/access/api/v1/projects/([^/]+)(/|$)`)
+var repositoryWriteUrl = regexp.MustCompile(`^(/artifactory/api/repositories/|/access/api/v1/projects/_/attach/repositories/)`)
+
+type cachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// ProjectKey is the project of the listing
+	ProjectKey string
+	// Repositories is true for the repository listings
+	Repositories bool
+}
+
+// responseCache is an http.RoundTripper caching the successful responses of the cacheableUrls by URL, for
+// one run (plan, apply, ...) of the provider. A write (i.e. not GET or HEAD) request to a project invalidates
+// the listings of the project, a repository write (including attach and detach) invalidates the repository
+// listings, and any other write clears the cache, except the usage reports.
+type responseCache struct {
+	transport http.RoundTripper
+
+	lock      sync.Mutex
+	responses map[string]cachedResponse
+	// generation is incremented on each invalidation, so a response fetched before a write is not cached
+	generation   int
+	hits, misses int
+}
+
+func newResponseCache(transport http.RoundTripper) *responseCache {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &responseCache{
+		transport: transport,
+		responses: map[string]cachedResponse{},
+	}
+}
+
+// cacheScope returns the project key of the listing and whether it is a repository listing, or false if the
+// request is not cacheable. Repository listings are only cached for a project.
+func cacheScope(req *http.Request) (projectKey string, repositories bool, ok bool) {
+	if req.Method != http.MethodGet {
+		return "", false, false
+	}
+
+	for _, url := range cacheableUrls {
+		match := url.FindStringSubmatch(req.URL.Path)
+		if match == nil {
+			continue
+		}
+		if match[1] == "repositories" {
+			projectKey := req.URL.Query().Get("project")
+			return projectKey, true, projectKey != ""
+		}
+		return match[1], false, true
+	}
+
+	return "", false, false
+}
+
+// invalidate removes the cached responses the write request may change
+func (c *responseCache) invalidate(req *http.Request) {
+	path := req.URL.Path
+	if path == usageUrl {
+		return
+	}
+
+	if repositoryWriteUrl.MatchString(path) {
+		c.clear(func(cached cachedResponse) bool { return cached.Repositories })
+		return
+	}
+
+	if match := projectWriteUrl.FindStringSubmatch(path); match != nil && match[1] != "_" {
+		c.clear(func(cached cachedResponse) bool { return cached.ProjectKey == match[1] })
+		return
+	}
+
+	c.Clear()
+}
+
+func (c *responseCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		c.invalidate(req)
+		return c.transport.RoundTrip(req)
+	}
+
+	projectKey, repositories, ok := cacheScope(req)
+	if !ok {
+		return c.transport.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+
+	c.lock.Lock()
+	cached, ok := c.responses[key]
+	generation := c.generation
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.lock.Unlock()
+
+	if ok {
+		tflog.Debug(req.Context(), fmt.Sprintf("responseCache: hit %s", key))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+			StatusCode:    cached.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        cached.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.lock.Lock()
+	if generation == c.generation {
+		c.responses[key] = cachedResponse{
+			StatusCode:   resp.StatusCode,
+			Header:       resp.Header.Clone(),
+			Body:         body,
+			ProjectKey:   projectKey,
+			Repositories: repositories,
+		}
+	}
+	c.lock.Unlock()
+
+	return resp, nil
+}
+
+// Clear removes all the cached responses
+func (c *responseCache) Clear() {
+	c.clear(func(cachedResponse) bool { return true })
+}
+
+// clear removes the cached responses matching the filter
+func (c *responseCache) clear(matches func(cachedResponse) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for key, cached := range c.responses {
+		if matches(cached) {
+			delete(c.responses, key)
+		}
+	}
+	c.generation++
+}
+
+// Stats returns the number of requests served from the cache and sent to the server
+func (c *responseCache) Stats() (hits, misses int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.hits, c.misses
+}
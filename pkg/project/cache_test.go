@@ -0,0 +1,154 @@
+package project
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestResponseCache(t *testing.T) {
+	var lock sync.Mutex
+	requests := map[string]int{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/artifactory/api/repositories":
+			w.Write([]byte(`[{"key":"test-repo1"},{"key":"test-repo2"}]`))
+		case r.URL.Path == "/access/api/v1/projects/test/roles":
+			w.Write([]byte(`[{"name":"Developer","type":"PREDEFINED","environments":["DEV"],"actions":["READ_REPOSITORY"]}]`))
+		case r.URL.Path == "/access/api/v1/projects/missing/roles":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	cache := newResponseCache(meta.Client.GetClient().Transport)
+	meta.Client.SetTransport(cache)
+	meta.Cache = cache
+
+	ctx := context.Background()
+	count := func(request string) int {
+		lock.Lock()
+		defer lock.Unlock()
+		return requests[request]
+	}
+
+	for i := 0; i < 3; i++ {
+		repoKeys, err := readRepos(ctx, "test", meta)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(repoKeys) != 2 {
+			t.Fatalf("expected 2 repos, got %v", repoKeys)
+		}
+		if _, err := readRoles(ctx, "test", meta); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if n := count("GET /artifactory/api/repositories"); n != 1 {
+		t.Errorf("expected repos to be fetched once, got %d", n)
+	}
+	if n := count("GET /access/api/v1/projects/test/roles"); n != 1 {
+		t.Errorf("expected roles to be fetched once, got %d", n)
+	}
+	if hits, misses := cache.Stats(); hits != 4 || misses != 2 {
+		t.Errorf("expected 4 hits and 2 misses, got %d and %d", hits, misses)
+	}
+
+	// not a listing
+	for i := 0; i < 2; i++ {
+		if _, err := meta.Client.R().Get("/access/api/v1/projects/test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := count("GET /access/api/v1/projects/test"); n != 2 {
+		t.Errorf("expected project to be fetched twice, got %d", n)
+	}
+
+	// errors are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := readRoles(ctx, "missing", meta); err == nil {
+			t.Fatal("expected error for missing project")
+		}
+	}
+	if n := count("GET /access/api/v1/projects/missing/roles"); n != 2 {
+		t.Errorf("expected missing roles to be fetched twice, got %d", n)
+	}
+
+	refetch := func() {
+		t.Helper()
+		if _, err := readRepos(ctx, "test", meta); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := readRoles(ctx, "test", meta); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	expectFetched := func(repos, roles int) {
+		t.Helper()
+		if n := count("GET /artifactory/api/repositories"); n != repos {
+			t.Errorf("expected repos to be fetched %d times, got %d", repos, n)
+		}
+		if n := count("GET /access/api/v1/projects/test/roles"); n != roles {
+			t.Errorf("expected roles to be fetched %d times, got %d", roles, n)
+		}
+	}
+
+	// usage reports and writes to other projects don't invalidate the cache
+	if _, err := meta.Client.R().SetBody(map[string]string{}).Post(usageUrl); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := meta.Client.R().SetBody(map[string]string{}).Put("/access/api/v1/projects/other"); err != nil {
+		t.Fatal(err)
+	}
+	refetch()
+	expectFetched(1, 1)
+
+	// attaching a repository invalidates the repository listings only
+	if err := addRepos(ctx, "test", []RepoKey{"test-repo3"}, meta); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	refetch()
+	expectFetched(2, 1)
+
+	// detaching a repository too
+	if err := deleteRepos(ctx, "test", []RepoKey{"test-repo3"}, meta); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	refetch()
+	expectFetched(3, 1)
+
+	// a write to the project invalidates its listings
+	if _, err := meta.Client.R().SetBody(map[string]string{}).Put("/access/api/v1/projects/test"); err != nil {
+		t.Fatal(err)
+	}
+	refetch()
+	expectFetched(4, 2)
+}
+
+func TestResponseCache_generation(t *testing.T) {
+	var cache *responseCache
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a write completes while the listing is being fetched
+		cache.Clear()
+		w.Write([]byte(`[]`))
+	}))
+	cache = newResponseCache(meta.Client.GetClient().Transport)
+	meta.Client.SetTransport(cache)
+
+	if _, err := meta.Client.R().Get("/access/api/v1/projects/test/roles"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cache.responses) != 0 {
+		t.Errorf("expected a response fetched before a write not to be cached, got %v", cache.responses)
+	}
+}
@@ -0,0 +1,72 @@
+package project
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const repositoryUrl = "/artifactory/api/repositories/{repoKey}"
+
+func projectBuildInfoRepositoryDataSource() *schema.Resource {
+	var projectBuildInfoRepositorySchema = map[string]*schema.Schema{
+		"project_key": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.ProjectKey,
+			Description:      "Project key of the build-info repository. This field supports only 2 - 20 lowercase alphanumeric and hyphen characters. Must begin with a letter.",
+		},
+		"key": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Key of the project's build-info repository, i.e. `<project_key>-build-info`.",
+		},
+		"package_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Package type of the repository, i.e. `buildinfo`.",
+		},
+	}
+
+	var readProjectBuildInfoRepository = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &util.ResourceData{ResourceData: data}
+		projectKey := d.GetString("project_key", false)
+
+		type Repository struct {
+			Key         string `json:"key"`
+			PackageType string `json:"packageType"`
+		}
+
+		var repo Repository
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetPathParam("repoKey", buildInfoRepoKey(projectKey)).
+			SetResult(&repo).
+			Get(repositoryUrl)
+		if err != nil {
+			return errorDiagnostics(data, withStep("reading build-info repository", newAPIError(resp, err)))
+		}
+
+		data.SetId(repo.Key)
+
+		setValue := util.MkLens(data)
+
+		setValue("key", repo.Key)
+		errors := setValue("package_type", repo.PackageType)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack project build-info repository %q", errors)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: readProjectBuildInfoRepository,
+
+		Schema:      projectBuildInfoRepositorySchema,
+		Description: "Reads the build-info repository of a project, where the project's build info is stored. Every project gets its own `<project_key>-build-info` repository.",
+	}
+}
@@ -0,0 +1,79 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestReadProjectBuildInfoRepository(t *testing.T) {
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet || r.URL.Path != "/artifactory/api/repositories/test-build-info" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"status":404,"message":"Repository not found"}]}`))
+			return
+		}
+		w.Write([]byte(`{"key":"test-build-info","rclass":"local","packageType":"buildinfo"}`))
+	}))
+
+	r := projectBuildInfoRepositoryDataSource()
+	data := r.TestResourceData()
+	data.Set("project_key", "test")
+
+	if diags := r.ReadContext(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+	if data.Id() != "test-build-info" || data.Get("key") != "test-build-info" || data.Get("package_type") != "buildinfo" {
+		t.Errorf("expected the build-info repository, got %s, %s and %s", data.Id(), data.Get("key"), data.Get("package_type"))
+	}
+
+	data.Set("project_key", "missing")
+	diags := r.ReadContext(context.Background(), data, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Repository not found") {
+		t.Errorf("expected decoded API error, got %v", diags)
+	}
+}
+
+func TestAccProjectBuildInfoRepository(t *testing.T) {
+	skipOnFakeServer(t, "build-info repositories")
+
+	name := "tftestprojects" + randSeq(10)
+	projectKey := strings.ToLower(randSeq(6))
+	dataSourceName := fmt.Sprintf("data.project_build_info_repository.%s", name)
+
+	config := fmt.Sprintf(`
+		resource "project" "%[1]s" {
+			key = "%[2]s"
+			display_name = "%[1]s"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+		}
+
+		data "project_build_info_repository" "%[1]s" {
+			project_key = project.%[1]s.key
+		}
+	`, name, projectKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      verifyDeleted("project."+name, verifyProject),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "key", fmt.Sprintf("%s-build-info", projectKey)),
+					resource.TestCheckResourceAttr(dataSourceName, "package_type", "buildinfo"),
+				),
+			},
+		},
+	})
+}
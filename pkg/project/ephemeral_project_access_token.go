@@ -0,0 +1,216 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const accessTokensUrl = "/access/api/v1/tokens"
+const accessTokenUrl = accessTokensUrl + "/{tokenId}"
+
+const defaultAccessTokenExpiresIn = 3600
+
+type AccessTokenRequest struct {
+	GrantType   string `json:"grant_type"`
+	Username    string `json:"username,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Refreshable bool   `json:"refreshable"`
+	Description string `json:"description,omitempty"`
+	ProjectKey  string `json:"project_key"`
+}
+
+type AccessTokenResponse struct {
+	TokenId     string `json:"token_id"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+	TokenType   string `json:"token_type"`
+}
+
+type ProjectAccessTokenEphemeralResourceModel struct {
+	ProjectKey  types.String `tfsdk:"project_key"`
+	Username    types.String `tfsdk:"username"`
+	Roles       types.Set    `tfsdk:"roles"`
+	Description types.String `tfsdk:"description"`
+	ExpiresIn   types.Int64  `tfsdk:"expires_in"`
+	TokenId     types.String `tfsdk:"token_id"`
+	AccessToken types.String `tfsdk:"access_token"`
+	Scope       types.String `tfsdk:"scope"`
+	TokenType   types.String `tfsdk:"token_type"`
+}
+
+// ProjectAccessTokenEphemeralResource issues a project scoped access token that only lives for the duration of the run.
+// The token is never persisted in state or plan and is revoked when Terraform closes the ephemeral resource.
+type ProjectAccessTokenEphemeralResource struct {
+	ProviderData ProviderMetadata
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ProjectAccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &ProjectAccessTokenEphemeralResource{}
+
+func NewProjectAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &ProjectAccessTokenEphemeralResource{}
+}
+
+func (r *ProjectAccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *ProjectAccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project_key": schema.StringAttribute{
+				Required:    true,
+				Description: "Project key the token is issued for. The token is scoped to this project.",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "The user name for which this token is created. Default to the user of the provider's access token.",
+			},
+			"roles": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "List of pre-defined Project or custom roles granted to the token in the project. When not set, the token carries the user's own project permissions.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Free text token description. Useful for filtering and managing tokens.",
+			},
+			"expires_in": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: fmt.Sprintf("The amount of time, in seconds, it would take for the token to expire. Default to %d.", defaultAccessTokenExpiresIn),
+			},
+			"token_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the issued token.",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token.",
+			},
+			"scope": schema.StringAttribute{
+				Computed:    true,
+				Description: "Scope of the issued token.",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the issued token.",
+			},
+		},
+		MarkdownDescription: "Issues a short-lived access token scoped to a project. The token only exists for the duration of the Terraform run: it is never stored in the plan or state, and it is revoked once Terraform is done with it. Typically used to configure downstream providers (e.g. `artifactory` provider aliases) per project.\n\n~>Requires Terraform 1.10 or later.",
+	}
+}
+
+func (r *ProjectAccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *ProjectAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ProjectAccessTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectKey := data.ProjectKey.ValueString()
+
+	expiresIn := int64(defaultAccessTokenExpiresIn)
+	if !data.ExpiresIn.IsNull() && !data.ExpiresIn.IsUnknown() {
+		expiresIn = data.ExpiresIn.ValueInt64()
+	}
+
+	tokenRequest := AccessTokenRequest{
+		GrantType:   "client_credentials",
+		Username:    data.Username.ValueString(),
+		ExpiresIn:   expiresIn,
+		Refreshable: false,
+		Description: data.Description.ValueString(),
+		ProjectKey:  projectKey,
+	}
+
+	if !data.Roles.IsNull() && !data.Roles.IsUnknown() {
+		var roles []string
+		resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if len(roles) > 0 {
+			tokenRequest.Scope = fmt.Sprintf("applied-permissions/roles:%s:%s", projectKey, strings.Join(roles, ","))
+		}
+	}
+
+	var tokenResponse AccessTokenResponse
+	_, err := r.ProviderData.Client.R().SetContext(ctx).
+		SetBody(tokenRequest).
+		SetResult(&tokenResponse).
+		Post(accessTokensUrl)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create project access token",
+			fmt.Sprintf("failed to create access token for project %s: %s", projectKey, err),
+		)
+		return
+	}
+
+	data.TokenId = types.StringValue(tokenResponse.TokenId)
+	data.AccessToken = types.StringValue(tokenResponse.AccessToken)
+	data.ExpiresIn = types.Int64Value(tokenResponse.ExpiresIn)
+	data.Scope = types.StringValue(tokenResponse.Scope)
+	data.TokenType = types.StringValue(tokenResponse.TokenType)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	// Private data must be valid JSON
+	privateTokenId, err := json.Marshal(tokenResponse.TokenId)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to store token ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, "token_id", privateTokenId)...)
+}
+
+func (r *ProjectAccessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	value, diags := req.Private.GetKey(ctx, "token_id")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(value) == 0 {
+		return
+	}
+
+	var tokenId string
+	if err := json.Unmarshal(value, &tokenId); err != nil || tokenId == "" {
+		return
+	}
+
+	httpResp, err := r.ProviderData.Client.R().SetContext(ctx).
+		SetPathParam("tokenId", tokenId).
+		Delete(accessTokenUrl)
+	if err != nil {
+		// Token may have expired already, nothing left to revoke
+		if httpResp != nil && httpResp.StatusCode() == http.StatusNotFound {
+			tflog.Warn(ctx, fmt.Sprintf("access token %s not found, skipping revocation", tokenId))
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Unable to revoke project access token",
+			fmt.Sprintf("failed to revoke access token %s, it will remain valid until it expires: %s", tokenId, err),
+		)
+	}
+}
@@ -0,0 +1,81 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+)
+
+func TestAccProjectAccessToken(t *testing.T) {
+	name := fmt.Sprintf("env-%s", randSeq(10))
+	projectKey := fmt.Sprintf("project-%s", strings.ToLower(randSeq(2)))
+	resourceName := fmt.Sprintf("project_environment.%s", name)
+
+	params := map[string]any{
+		"env_id":      name,
+		"name":        name,
+		"project_key": projectKey,
+	}
+
+	projectConfig := test.ExecuteTemplate("TestAccProjectAccessToken", `
+		resource "project" "{{ .project_key }}" {
+			key          = "{{ .project_key }}"
+			display_name = "{{ .project_key }}"
+			admin_privileges {
+				manage_members   = true
+				manage_resources = true
+				index_resources  = true
+			}
+		}
+	`, params)
+
+	// The environment is created through a provider configured with the ephemeral project token
+	tokenConfig := test.ExecuteTemplate("TestAccProjectAccessToken", `
+		resource "project" "{{ .project_key }}" {
+			key          = "{{ .project_key }}"
+			display_name = "{{ .project_key }}"
+			admin_privileges {
+				manage_members   = true
+				manage_resources = true
+				index_resources  = true
+			}
+		}
+
+		ephemeral "project_access_token" "{{ .project_key }}" {
+			project_key = project.{{ .project_key }}.key
+			expires_in  = 300
+		}
+
+		provider "project" {
+			alias         = "scoped"
+			access_token  = ephemeral.project_access_token.{{ .project_key }}.access_token
+			check_license = false
+		}
+
+		resource "project_environment" "{{ .env_id }}" {
+			provider    = project.scoped
+			name        = "{{ .name }}"
+			project_key = project.{{ .project_key }}.key
+		}
+	`, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: projectConfig,
+			},
+			{
+				Config: tokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "project_key", projectKey),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,257 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ErrNotFound matches (with errors.Is) API errors with 404 status, e.g. when the project was deleted out-of-band
+var ErrNotFound = errors.New("not found")
+
+// requestIdHeaders are the response headers checked, in order, for the ID of the request
+var requestIdHeaders = []string{"X-Request-Id", "X-JFrog-Request-Id"}
+
+type AccessError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type AccessErrorResponse struct {
+	Errors []AccessError `json:"errors"`
+}
+
+// APIError is a non-2xx response from the JFrog Platform, with the errors decoded from the response body
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	RequestId  string
+	Errors     []AccessError
+	err        error
+}
+
+func (e *APIError) Error() string {
+	messages := e.Messages()
+	if messages == "" {
+		messages = http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("%d %s %s: %s", e.StatusCode, e.Method, e.URL, messages)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// Messages returns the messages of the decoded errors, separated by semicolons
+func (e *APIError) Messages() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// HasCode returns true if any of the decoded errors has the code, e.g. NOT_FOUND
+func (e *APIError) HasCode(code string) bool {
+	for _, err := range e.Errors {
+		if err.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newAPIError decodes the Access API error body (`{"errors":[{"code":"...","message":"..."}]}`) of a failed
+// request into an APIError. Errors without a response (e.g. connection errors) are returned unchanged.
+func newAPIError(resp *resty.Response, err error) error {
+	if err == nil || resp == nil || resp.StatusCode() < http.StatusBadRequest {
+		return err
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode(),
+		err:        err,
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL
+	}
+
+	for _, header := range requestIdHeaders {
+		if requestId := resp.Header().Get(header); requestId != "" {
+			apiErr.RequestId = requestId
+			break
+		}
+	}
+
+	var errorResp AccessErrorResponse
+	if json.Unmarshal(resp.Body(), &errorResp) == nil {
+		apiErr.Errors = errorResp.Errors
+	}
+
+	// Some endpoints (e.g. Artifactory) return a plain text body
+	if len(apiErr.Errors) == 0 && len(resp.Body()) > 0 && !json.Valid(resp.Body()) {
+		apiErr.Errors = []AccessError{{Message: strings.TrimSpace(resp.String())}}
+	}
+
+	return apiErr
+}
+
+// elementError is the failure of an API call for one element (identified by name) of a set attribute,
+// e.g. a `member` of the project. It allows the diagnostic to point at the attribute and name the element.
+type elementError struct {
+	Attribute string
+	Name      string
+	err       error
+}
+
+func (e *elementError) Error() string {
+	return e.err.Error()
+}
+
+func (e *elementError) Unwrap() error {
+	return e.err
+}
+
+// elementField returns the nested attribute of the element the API error message refers to, if any
+func elementField(attribute, message string) string {
+	message = strings.ToLower(message)
+
+	switch attribute {
+	case "member", "group":
+		if strings.Contains(message, "role") {
+			return "roles"
+		}
+		return "name"
+	case "role":
+		if strings.Contains(message, "action") {
+			return "actions"
+		}
+		if strings.Contains(message, "environment") {
+			return "environments"
+		}
+	}
+
+	return ""
+}
+
+// elementDetail describes the named element of the set attribute the error is about, e.g.
+// `Element: member "user1", attribute roles`. Diagnostics can't point at a set element (elements of a set have no
+// index), so they point at the set attribute and the element is named in the detail instead.
+func elementDetail(data *schema.ResourceData, e *elementError, field string) string {
+	detail := fmt.Sprintf("Element: %s %q", e.Attribute, e.Name)
+	if field != "" {
+		detail += fmt.Sprintf(", attribute %s", field)
+	}
+
+	if data == nil {
+		return detail
+	}
+	set, ok := data.Get(e.Attribute).(*schema.Set)
+	if !ok {
+		return detail
+	}
+
+	for _, element := range set.List() {
+		name := element
+		if m, ok := element.(map[string]interface{}); ok {
+			name = m["name"]
+		}
+		if name == e.Name {
+			return detail
+		}
+	}
+
+	// e.g. copied from a template
+	return detail + " (not in the configuration)"
+}
+
+// stepError labels err with the step of the operation that was running, e.g. "updating members"
+type stepError struct {
+	Step string
+	err  error
+}
+
+func (e *stepError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stepError) Unwrap() error {
+	return e.err
+}
+
+func withStep(step string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stepError{Step: step, err: err}
+}
+
+// errorDiagnostics converts err into an error diagnostic. API errors include the HTTP status, the request ID
+// and the decoded error codes, errors of set elements point at the set attribute and name the element, and
+// timeouts name the step that was running.
+func errorDiagnostics(data *schema.ResourceData, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+	}
+	details := []string{}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		details = append(details, fmt.Sprintf("HTTP status: %d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode)))
+		if apiErr.RequestId != "" {
+			details = append(details, fmt.Sprintf("Request ID: %s", apiErr.RequestId))
+		}
+		for _, e := range apiErr.Errors {
+			if e.Code != "" {
+				details = append(details, fmt.Sprintf("%s: %s", e.Code, e.Message))
+			}
+		}
+	}
+
+	var elemErr *elementError
+	if errors.As(err, &elemErr) {
+		message := ""
+		if apiErr != nil {
+			message = apiErr.Messages()
+		}
+		diagnostic.AttributePath = cty.GetAttrPath(elemErr.Attribute)
+		details = append(details, elementDetail(data, elemErr, elementField(elemErr.Attribute, message)))
+	}
+	diagnostic.Detail = strings.Join(details, "\n")
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		step := "running the operation"
+		var stepErr *stepError
+		if errors.As(err, &stepErr) {
+			step = stepErr.Step
+		}
+
+		diagnostic.Summary = fmt.Sprintf("timed out while %s", step)
+		diagnostic.Detail = fmt.Sprintf("%s\n\nThe operation did not complete within the configured timeout. It can be increased with the `timeouts` block of the resource.", err)
+	}
+
+	return diag.Diagnostics{diagnostic}
+}
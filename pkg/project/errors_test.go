@@ -0,0 +1,138 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestErrorDiagnostics_memberRoles(t *testing.T) {
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"members":[]}`))
+			return
+		}
+
+		w.Header().Set("X-Request-Id", "abc123")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":[{"code":"BAD_REQUEST","message":"Role 'unknown' does not exist"}]}`))
+	}))
+
+	data := projectResource().TestResourceData()
+	data.Set("member", []interface{}{
+		map[string]interface{}{"name": "user1", "roles": []interface{}{"unknown"}},
+	})
+	members := unpackMembers(data, "member")
+
+	_, err := updateMembers(context.Background(), "test", usersMembershipType, membershipManagementAuthoritative, members, meta)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.RequestId != "abc123" || !apiErr.HasCode("BAD_REQUEST") {
+		t.Errorf("unexpected APIError: %+v", apiErr)
+	}
+
+	diags := errorDiagnostics(data, err)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diags)
+	}
+
+	// set elements have no index, the diagnostic points at the set and names the element
+	expectedPath := cty.GetAttrPath("member")
+	if !diags[0].AttributePath.Equals(expectedPath) {
+		t.Errorf("expected path %#v, got %#v", expectedPath, diags[0].AttributePath)
+	}
+	for _, expected := range []string{"HTTP status: 400 Bad Request", "Request ID: abc123", "BAD_REQUEST: Role 'unknown' does not exist", `Element: member "user1", attribute roles`} {
+		if !strings.Contains(diags[0].Detail, expected) {
+			t.Errorf("expected detail to contain %q, got %q", expected, diags[0].Detail)
+		}
+	}
+}
+
+func TestErrorDiagnostics_elementNotConfigured(t *testing.T) {
+	data := projectResource().TestResourceData()
+	data.Set("member", []interface{}{
+		map[string]interface{}{"name": "user1", "roles": []interface{}{"Developer"}},
+	})
+
+	diags := errorDiagnostics(data, &elementError{Attribute: "member", Name: "copied", err: errors.New("failed")})
+	if expectedPath := cty.GetAttrPath("member"); !diags[0].AttributePath.Equals(expectedPath) {
+		t.Errorf("expected path %#v, got %#v", expectedPath, diags[0].AttributePath)
+	}
+	if expected := `Element: member "copied", attribute name (not in the configuration)`; diags[0].Detail != expected {
+		t.Errorf("expected detail %q, got %q", expected, diags[0].Detail)
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	restyClient := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"code":"NOT_FOUND","message":"Project 'test' not found"}]}`))
+		case "/text":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("Web server is down"))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})).Client
+
+	resp, err := restyClient.R().Get("/json")
+	apiErr := newAPIError(resp, err)
+	if !errors.Is(apiErr, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", apiErr)
+	}
+	if !strings.HasSuffix(apiErr.Error(), "Project 'test' not found") {
+		t.Errorf("unexpected message: %s", apiErr)
+	}
+
+	resp, err = restyClient.R().Get("/text")
+	apiErr = newAPIError(resp, err)
+	if errors.Is(apiErr, ErrNotFound) {
+		t.Errorf("expected error not to be ErrNotFound")
+	}
+	if !strings.HasSuffix(apiErr.Error(), "Web server is down") {
+		t.Errorf("unexpected message: %s", apiErr)
+	}
+
+	resp, err = restyClient.R().Get("/ok")
+	if newAPIError(resp, err) != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestErrorDiagnostics_timeout(t *testing.T) {
+	restyClient := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})).Client
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	resp, err := restyClient.R().SetContext(ctx).Get("/access/api/v1/projects/test/users")
+	diags := errorDiagnostics(nil, withStep("reading members", newAPIError(resp, err)))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diags)
+	}
+	if diags[0].Summary != "timed out while reading members" {
+		t.Errorf("unexpected summary: %s", diags[0].Summary)
+	}
+	if !strings.Contains(diags[0].Detail, "`timeouts` block") {
+		t.Errorf("unexpected detail: %s", diags[0].Detail)
+	}
+}
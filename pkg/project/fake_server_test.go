@@ -0,0 +1,1018 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/exp/slices"
+)
+
+// fakeAccessToken is the admin access token accepted by the fake JFrog Platform
+const fakeAccessToken = "fake-admin-token"
+
+var fakeProjectKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{1,31}$`)
+
+// fakePredefinedRoles are the roles of every project
+var fakePredefinedRoles = []Role{
+	{Name: "Project Admin", Type: "ADMIN", Environments: validRoleEnvironments, Actions: []string{"READ_REPOSITORY", "ANNOTATE_REPOSITORY", "DEPLOY_CACHE_REPOSITORY", "DELETE_OVERWRITE_REPOSITORY", "MANAGE_XRAY_MD_REPOSITORY"}},
+	{Name: "Developer", Type: "PREDEFINED", Environments: []string{"DEV"}, Actions: []string{"READ_REPOSITORY", "ANNOTATE_REPOSITORY", "DEPLOY_CACHE_REPOSITORY", "DELETE_OVERWRITE_REPOSITORY"}},
+	{Name: "Contributor", Type: "PREDEFINED", Environments: []string{"DEV"}, Actions: []string{"READ_REPOSITORY", "ANNOTATE_REPOSITORY", "DEPLOY_CACHE_REPOSITORY"}},
+	{Name: "Viewer", Type: "PREDEFINED", Environments: validRoleEnvironments, Actions: []string{"READ_REPOSITORY"}},
+	{Name: "Release Manager", Type: "PREDEFINED", Environments: validRoleEnvironments, Actions: []string{"READ_REPOSITORY", "ANNOTATE_REPOSITORY", "DEPLOY_CACHE_REPOSITORY", "DELETE_OVERWRITE_REPOSITORY", "READ_RELEASE_BUNDLE", "DEPLOY_RELEASE_BUNDLE"}},
+	{Name: "Security Manager", Type: "PREDEFINED", Environments: validRoleEnvironments, Actions: []string{"READ_REPOSITORY", "MANAGE_XRAY_MD_REPOSITORY", "TRIGGER_PIPELINE", "MANAGE_XRAY_WATCHES", "MANAGE_POLICIES"}},
+}
+
+type fakeProject struct {
+	Project
+	members      map[string]map[string]Member // membership type -> name -> member
+	roles        map[string]Role
+	environments map[string]struct{}
+}
+
+type fakeRepo struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	PackageType string `json:"packageType"`
+	ProjectKey  string `json:"-"`
+	UsedSpace   int64  `json:"-"`
+}
+
+// fakeJFrog is an in-process fake of the Access and Artifactory REST API endpoints used by the provider:
+// projects, members, roles, environments, repository listing and assignment, and project access tokens.
+// Errors are returned with the same status codes and bodies as the JFrog Platform, so the acceptance tests
+// can run against it (see fakeServerEnv). Builds, build retention and Xray are not implemented.
+type fakeJFrog struct {
+	lock     sync.Mutex
+	projects map[string]*fakeProject
+	users    map[string]struct{}
+	groups   map[string]struct{}
+	repos    map[string]*fakeRepo
+	tokens   map[string]string // token ID -> access token
+	lastId   int
+	requests []string
+	mux      *http.ServeMux
+	// pageMembers pages the member listings with a cursor, by the requested limit
+	pageMembers bool
+}
+
+func newFakeJFrog() *fakeJFrog {
+	f := &fakeJFrog{
+		projects: map[string]*fakeProject{},
+		users:    map[string]struct{}{},
+		groups:   map[string]struct{}{},
+		repos:    map[string]*fakeRepo{},
+		tokens:   map[string]string{},
+		mux:      http.NewServeMux(),
+	}
+
+	f.handle("GET /artifactory/api/system/license", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"type": "Enterprise Plus", "validThrough": "Dec 31, 2099", "licensedTo": "fake"})
+	})
+	f.handle("GET /artifactory/api/system/version", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"version": "7.98.0", "revision": "79800900"})
+	})
+	f.handle("POST /artifactory/api/system/usage", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	f.handle("PUT /artifactory/api/security/users/{name}", f.putPrincipal(f.users))
+	f.handle("DELETE /artifactory/api/security/users/{name}", f.deletePrincipal(usersMembershipType, f.users))
+	f.handle("PUT /artifactory/api/security/groups/{name}", f.putPrincipal(f.groups))
+	f.handle("DELETE /artifactory/api/security/groups/{name}", f.deletePrincipal(groupssMembershipType, f.groups))
+
+	f.handle("GET /artifactory/api/repositories", f.listRepos)
+	f.handle("GET /artifactory/api/repositories/{repoKey}", f.getRepo)
+	f.handle("PUT /artifactory/api/repositories/{repoKey}", f.createRepo)
+	f.handle("DELETE /artifactory/api/repositories/{repoKey}", f.deleteRepo)
+	f.handle("GET "+storageInfoUrl, f.storageInfo)
+
+	f.handle("GET /access/api/v1/projects", f.listProjects)
+	f.handle("POST /access/api/v1/projects", f.createProject)
+	f.handle("GET /access/api/v1/projects/{projectKey}", f.project(f.getProject))
+	f.handle("PUT /access/api/v1/projects/{projectKey}", f.project(f.updateProject))
+	f.handle("DELETE /access/api/v1/projects/{projectKey}", f.project(f.deleteProject))
+
+	for _, membershipType := range []string{usersMembershipType, groupssMembershipType} {
+		f.handle("GET /access/api/v1/projects/{projectKey}/"+membershipType, f.project(f.listMembers(membershipType)))
+		f.handle("GET /access/api/v1/projects/{projectKey}/"+membershipType+"/{memberName}", f.project(f.getMember(membershipType)))
+		f.handle("PUT /access/api/v1/projects/{projectKey}/"+membershipType+"/{memberName}", f.project(f.putMember(membershipType)))
+		f.handle("DELETE /access/api/v1/projects/{projectKey}/"+membershipType+"/{memberName}", f.project(f.deleteMember(membershipType)))
+	}
+
+	f.handle("GET /access/api/v1/projects/{projectKey}/roles", f.project(f.listRoles))
+	f.handle("POST /access/api/v1/projects/{projectKey}/roles", f.project(f.createRole))
+	f.handle("GET /access/api/v1/projects/{projectKey}/roles/{roleName}", f.project(f.getRole))
+	f.handle("PUT /access/api/v1/projects/{projectKey}/roles/{roleName}", f.project(f.updateRole))
+	f.handle("DELETE /access/api/v1/projects/{projectKey}/roles/{roleName}", f.project(f.deleteRole))
+
+	f.handle("GET /access/api/v1/projects/{projectKey}/environments", f.project(f.listEnvironments))
+	f.handle("POST /access/api/v1/projects/{projectKey}/environments", f.project(f.createEnvironment))
+	f.handle("POST /access/api/v1/projects/{projectKey}/environments/{environmentName}/rename", f.project(f.renameEnvironment))
+	f.handle("DELETE /access/api/v1/projects/{projectKey}/environments/{environmentName}", f.project(f.deleteEnvironment))
+
+	f.handle("PUT /access/api/v1/projects/_/attach/repositories/{repoKey}/{projectKey}", f.attachRepo)
+	f.handle("DELETE /access/api/v1/projects/_/attach/repositories/{repoKey}", f.detachRepo)
+
+	f.handle("POST "+accessTokensUrl, f.createToken)
+	f.handle("DELETE "+accessTokensUrl+"/{tokenId}", f.revokeToken)
+
+	return f
+}
+
+func (f *fakeJFrog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mux.ServeHTTP(w, r)
+}
+
+// handle registers the handler, which is run with the lock held once the request is authenticated
+func (f *fakeJFrog) handle(pattern string, handler http.HandlerFunc) {
+	f.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		f.lastId++
+		w.Header().Set("X-Request-Id", fmt.Sprintf("fake-%d", f.lastId))
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+		if !f.authorized(r) {
+			accessError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Bad credentials")
+			return
+		}
+
+		handler(w, r)
+	})
+}
+
+func (f *fakeJFrog) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	return token == fakeAccessToken || slices.Contains(mapValues(f.tokens), token)
+}
+
+// requestCount returns the number of requests received with the method and path, e.g. `GET /access/api/v1/projects/test`
+func (f *fakeJFrog) requestCount(request string) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	count := 0
+	for _, r := range f.requests {
+		if r == request {
+			count++
+		}
+	}
+	return count
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// accessError writes an Access API error, e.g. `{"errors":[{"code":"NOT_FOUND","message":"..."}]}`
+func accessError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, AccessErrorResponse{Errors: []AccessError{{Code: code, Message: message}}})
+}
+
+// artifactoryError writes an Artifactory API error, e.g. `{"errors":[{"status":404,"message":"..."}]}`
+func artifactoryError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]any{{"status": status, "message": message}},
+	})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func mapValues[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *fakeJFrog) putPrincipal(principals map[string]struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		if _, ok := principals[name]; ok {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		principals[name] = struct{}{}
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (f *fakeJFrog) deletePrincipal(membershipType string, principals map[string]struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		if _, ok := principals[name]; !ok {
+			artifactoryError(w, http.StatusNotFound, fmt.Sprintf("%s '%s' does not exist", principalType(membershipType), name))
+			return
+		}
+		delete(principals, name)
+		for _, project := range f.projects {
+			delete(project.members[membershipType], name)
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func principalType(membershipType string) string {
+	if membershipType == groupssMembershipType {
+		return "Group"
+	}
+	return "User"
+}
+
+func (f *fakeJFrog) listRepos(w http.ResponseWriter, r *http.Request) {
+	projectKey := r.URL.Query().Get("project")
+
+	repos := []*fakeRepo{}
+	for _, key := range sortedKeys(f.repos) {
+		if projectKey == "" || f.repos[key].ProjectKey == projectKey {
+			repos = append(repos, f.repos[key])
+		}
+	}
+	writeJSON(w, http.StatusOK, repos)
+}
+
+func (f *fakeJFrog) getRepo(w http.ResponseWriter, r *http.Request) {
+	repo, ok := f.repos[r.PathValue("repoKey")]
+	if !ok {
+		artifactoryError(w, http.StatusBadRequest, "Bad Request")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"key": repo.Key, "rclass": strings.ToLower(repo.Type), "packageType": repo.PackageType, "projectKey": repo.ProjectKey})
+}
+
+func (f *fakeJFrog) createRepo(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("repoKey")
+
+	var body struct {
+		RClass      string `json:"rclass"`
+		PackageType string `json:"packageType"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	for existing := range f.repos {
+		if strings.EqualFold(existing, key) {
+			artifactoryError(w, http.StatusBadRequest, "Case insensitive repository key already exists")
+			return
+		}
+	}
+	if body.RClass == "" {
+		artifactoryError(w, http.StatusBadRequest, "Repository rclass is mandatory")
+		return
+	}
+	if body.PackageType == "" {
+		body.PackageType = "generic"
+	}
+
+	f.repos[key] = &fakeRepo{Key: key, Type: strings.ToUpper(body.RClass), PackageType: body.PackageType}
+	w.Write([]byte(fmt.Sprintf("Successfully created repository '%s'", key)))
+}
+
+func (f *fakeJFrog) deleteRepo(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("repoKey")
+	if _, ok := f.repos[key]; !ok {
+		artifactoryError(w, http.StatusNotFound, fmt.Sprintf("Repository %s not found", key))
+		return
+	}
+	delete(f.repos, key)
+	w.Write([]byte(fmt.Sprintf("Repository '%s' and all its content have been removed successfully.", key)))
+}
+
+func (f *fakeJFrog) storageInfo(w http.ResponseWriter, r *http.Request) {
+	summaries := []RepositorySummary{}
+	for _, key := range sortedKeys(f.repos) {
+		summaries = append(summaries, RepositorySummary{RepoKey: key, UsedSpaceInBytes: f.repos[key].UsedSpace})
+	}
+	summaries = append(summaries, RepositorySummary{RepoKey: "TOTAL"})
+	writeJSON(w, http.StatusOK, StorageInfo{RepositoriesSummaryList: summaries})
+}
+
+func (f *fakeJFrog) listProjects(w http.ResponseWriter, r *http.Request) {
+	projects := []Project{}
+	for _, key := range sortedKeys(f.projects) {
+		projects = append(projects, f.projects[key].Project)
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+// displayNameTaken returns true if another project than projectKey has the display name
+func (f *fakeJFrog) displayNameTaken(projectKey, displayName string) bool {
+	for key, project := range f.projects {
+		if key != projectKey && strings.EqualFold(project.DisplayName, displayName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeJFrog) createProject(w http.ResponseWriter, r *http.Request) {
+	var project Project
+	if !decodeBody(w, r, &project) {
+		return
+	}
+
+	if !fakeProjectKeyRegex.MatchString(project.Key) {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Invalid project key '%s'", project.Key))
+		return
+	}
+	if project.DisplayName == "" {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", "Project display name is mandatory")
+		return
+	}
+	if _, ok := f.projects[project.Key]; ok {
+		accessError(w, http.StatusConflict, "CONFLICT", fmt.Sprintf("Project with key '%s' already exists", project.Key))
+		return
+	}
+	if f.displayNameTaken(project.Key, project.DisplayName) {
+		accessError(w, http.StatusConflict, "CONFLICT", fmt.Sprintf("Project with display name '%s' already exists", project.DisplayName))
+		return
+	}
+
+	f.projects[project.Key] = &fakeProject{
+		Project: project,
+		members: map[string]map[string]Member{
+			usersMembershipType:   {},
+			groupssMembershipType: {},
+		},
+		roles:        map[string]Role{},
+		environments: map[string]struct{}{},
+	}
+	writeJSON(w, http.StatusCreated, project)
+}
+
+// project looks up the project of the projectKey path value for the handler, or returns a 404
+func (f *fakeJFrog) project(handler func(http.ResponseWriter, *http.Request, *fakeProject)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		projectKey := r.PathValue("projectKey")
+		project, ok := f.projects[projectKey]
+		if !ok {
+			accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Project '%s' not found", projectKey))
+			return
+		}
+		handler(w, r, project)
+	}
+}
+
+func (f *fakeJFrog) getProject(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	writeJSON(w, http.StatusOK, project.Project)
+}
+
+func (f *fakeJFrog) updateProject(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	var update Project
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if update.DisplayName == "" {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", "Project display name is mandatory")
+		return
+	}
+	if f.displayNameTaken(project.Key, update.DisplayName) {
+		accessError(w, http.StatusConflict, "CONFLICT", fmt.Sprintf("Project with display name '%s' already exists", update.DisplayName))
+		return
+	}
+
+	update.Key = project.Key
+	project.Project = update
+	writeJSON(w, http.StatusOK, project.Project)
+}
+
+func (f *fakeJFrog) deleteProject(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	for _, repo := range f.repos {
+		if repo.ProjectKey == project.Key {
+			accessError(w, http.StatusBadRequest, "BAD_REQUEST", "project containing resources can't be removed")
+			return
+		}
+	}
+
+	delete(f.projects, project.Key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeJFrog) listMembers(membershipType string) func(http.ResponseWriter, *http.Request, *fakeProject) {
+	return func(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+		members := []Member{}
+		for _, name := range sortedKeys(project.members[membershipType]) {
+			members = append(members, project.members[membershipType][name])
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if !f.pageMembers || err != nil || limit <= 0 {
+			writeJSON(w, http.StatusOK, map[string]any{"members": members})
+			return
+		}
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		start = min(start, len(members))
+		end := min(start+limit, len(members))
+		body := map[string]any{"members": members[start:end]}
+		if end < len(members) {
+			body["cursor"] = strconv.Itoa(end)
+		}
+		writeJSON(w, http.StatusOK, body)
+	}
+}
+
+func (f *fakeJFrog) getMember(membershipType string) func(http.ResponseWriter, *http.Request, *fakeProject) {
+	return func(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+		name := r.PathValue("memberName")
+		member, ok := project.members[membershipType][name]
+		if !ok {
+			accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s '%s' is not a member of project '%s'", principalType(membershipType), name, project.Key))
+			return
+		}
+		writeJSON(w, http.StatusOK, member)
+	}
+}
+
+// roleNames returns the names of the predefined and custom roles of the project
+func (project *fakeProject) roleNames() []string {
+	names := sortedKeys(project.roles)
+	for _, role := range fakePredefinedRoles {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+func (f *fakeJFrog) putMember(membershipType string) func(http.ResponseWriter, *http.Request, *fakeProject) {
+	return func(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+		name := r.PathValue("memberName")
+
+		var member Member
+		if !decodeBody(w, r, &member) {
+			return
+		}
+
+		principals := f.users
+		if membershipType == groupssMembershipType {
+			principals = f.groups
+		}
+		if _, ok := principals[name]; !ok {
+			accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s '%s' does not exist", principalType(membershipType), name))
+			return
+		}
+		if len(member.Roles) == 0 {
+			accessError(w, http.StatusBadRequest, "BAD_REQUEST", "At least one role is required")
+			return
+		}
+		roleNames := project.roleNames()
+		for _, role := range member.Roles {
+			if !slices.Contains(roleNames, role) {
+				accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Role '%s' does not exist in project '%s'", role, project.Key))
+				return
+			}
+		}
+
+		member.Name = name
+		project.members[membershipType][name] = member
+		writeJSON(w, http.StatusOK, member)
+	}
+}
+
+func (f *fakeJFrog) deleteMember(membershipType string) func(http.ResponseWriter, *http.Request, *fakeProject) {
+	return func(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+		name := r.PathValue("memberName")
+		if _, ok := project.members[membershipType][name]; !ok {
+			accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s '%s' is not a member of project '%s'", principalType(membershipType), name, project.Key))
+			return
+		}
+		delete(project.members[membershipType], name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeJFrog) listRoles(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	roles := slices.Clone(fakePredefinedRoles)
+	for _, name := range sortedKeys(project.roles) {
+		roles = append(roles, project.roles[name])
+	}
+	writeJSON(w, http.StatusOK, roles)
+}
+
+func findPredefinedRole(name string) (Role, bool) {
+	for _, role := range fakePredefinedRoles {
+		if role.Name == name {
+			return role, true
+		}
+	}
+	return Role{}, false
+}
+
+func (f *fakeJFrog) getRole(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	name := r.PathValue("roleName")
+	if role, ok := findPredefinedRole(name); ok {
+		writeJSON(w, http.StatusOK, role)
+		return
+	}
+	role, ok := project.roles[name]
+	if !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Role '%s' not found in project '%s'", name, project.Key))
+		return
+	}
+	writeJSON(w, http.StatusOK, role)
+}
+
+// validateRole writes an error and returns false if the role is not valid for the project
+func (project *fakeProject) validateRole(w http.ResponseWriter, role Role) bool {
+	if role.Type != customRoleType {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Invalid role type '%s', only %s roles can be managed", role.Type, customRoleType))
+		return false
+	}
+	if len(role.Environments) == 0 {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", "At least one environment is required")
+		return false
+	}
+	if len(role.Actions) == 0 {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", "At least one action is required")
+		return false
+	}
+	for _, env := range role.Environments {
+		if _, ok := project.environments[env]; !ok && !slices.Contains(validRoleEnvironments, env) {
+			accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Environment '%s' does not exist", env))
+			return false
+		}
+	}
+	for _, action := range role.Actions {
+		if !slices.Contains(validRoleActions, action) {
+			accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Invalid action '%s'", action))
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeJFrog) createRole(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	var role Role
+	if !decodeBody(w, r, &role) {
+		return
+	}
+	if role.Name == "" {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", "Role name is mandatory")
+		return
+	}
+	if _, ok := findPredefinedRole(role.Name); ok {
+		accessError(w, http.StatusConflict, "CONFLICT", fmt.Sprintf("Role '%s' already exists in project '%s'", role.Name, project.Key))
+		return
+	}
+	if _, ok := project.roles[role.Name]; ok {
+		accessError(w, http.StatusConflict, "CONFLICT", fmt.Sprintf("Role '%s' already exists in project '%s'", role.Name, project.Key))
+		return
+	}
+	if !project.validateRole(w, role) {
+		return
+	}
+
+	project.roles[role.Name] = role
+	writeJSON(w, http.StatusCreated, role)
+}
+
+func (f *fakeJFrog) updateRole(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	name := r.PathValue("roleName")
+	if _, ok := findPredefinedRole(name); ok {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Predefined role '%s' can't be modified", name))
+		return
+	}
+	if _, ok := project.roles[name]; !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Role '%s' not found in project '%s'", name, project.Key))
+		return
+	}
+
+	var role Role
+	if !decodeBody(w, r, &role) {
+		return
+	}
+	role.Name = name
+	if !project.validateRole(w, role) {
+		return
+	}
+
+	project.roles[name] = role
+	writeJSON(w, http.StatusOK, role)
+}
+
+func (f *fakeJFrog) deleteRole(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	name := r.PathValue("roleName")
+	if _, ok := findPredefinedRole(name); ok {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Predefined role '%s' can't be deleted", name))
+		return
+	}
+	if _, ok := project.roles[name]; !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Role '%s' not found in project '%s'", name, project.Key))
+		return
+	}
+
+	delete(project.roles, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeJFrog) listEnvironments(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	envs := []ProjectEnvironment{}
+	for _, name := range validRoleEnvironments {
+		envs = append(envs, ProjectEnvironment{Name: name})
+	}
+	for _, name := range sortedKeys(project.environments) {
+		envs = append(envs, ProjectEnvironment{Name: name})
+	}
+	writeJSON(w, http.StatusOK, envs)
+}
+
+// validateEnvironmentName writes an error and returns false if the name is not a free project environment name
+func (project *fakeProject) validateEnvironmentName(w http.ResponseWriter, name string) bool {
+	if !strings.HasPrefix(name, project.Key+"-") || len(name) == len(project.Key)+1 {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Environment name '%s' must start with the project key prefix '%s-'", name, project.Key))
+		return false
+	}
+	if _, ok := project.environments[name]; ok || slices.Contains(validRoleEnvironments, name) {
+		accessError(w, http.StatusConflict, "CONFLICT", fmt.Sprintf("Environment '%s' already exists", name))
+		return false
+	}
+	return true
+}
+
+func (f *fakeJFrog) createEnvironment(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	var env ProjectEnvironment
+	if !decodeBody(w, r, &env) {
+		return
+	}
+	if !project.validateEnvironmentName(w, env.Name) {
+		return
+	}
+
+	project.environments[env.Name] = struct{}{}
+	writeJSON(w, http.StatusCreated, env)
+}
+
+func (f *fakeJFrog) renameEnvironment(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	name := r.PathValue("environmentName")
+	if _, ok := project.environments[name]; !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Environment '%s' not found", name))
+		return
+	}
+
+	var update ProjectEnvironmentUpdate
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if !project.validateEnvironmentName(w, update.NewName) {
+		return
+	}
+
+	delete(project.environments, name)
+	project.environments[update.NewName] = struct{}{}
+	for roleName, role := range project.roles {
+		if i := slices.Index(role.Environments, name); i >= 0 {
+			role.Environments = slices.Clone(role.Environments)
+			role.Environments[i] = update.NewName
+			project.roles[roleName] = role
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeJFrog) deleteEnvironment(w http.ResponseWriter, r *http.Request, project *fakeProject) {
+	name := r.PathValue("environmentName")
+	if slices.Contains(validRoleEnvironments, name) {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("Global environment '%s' can't be deleted from a project", name))
+		return
+	}
+	if _, ok := project.environments[name]; !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Environment '%s' not found", name))
+		return
+	}
+
+	delete(project.environments, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeJFrog) attachRepo(w http.ResponseWriter, r *http.Request) {
+	repoKey, projectKey := r.PathValue("repoKey"), r.PathValue("projectKey")
+
+	repo, ok := f.repos[repoKey]
+	if !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Repository '%s' not found", repoKey))
+		return
+	}
+	if _, ok := f.projects[projectKey]; !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Project '%s' not found", projectKey))
+		return
+	}
+	if repo.ProjectKey != "" && repo.ProjectKey != projectKey && r.URL.Query().Get("force") != "true" {
+		accessError(w, http.StatusBadRequest, "BAD_REQUEST", "Repository is already assigned to another project")
+		return
+	}
+
+	repo.ProjectKey = projectKey
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeJFrog) detachRepo(w http.ResponseWriter, r *http.Request) {
+	repoKey := r.PathValue("repoKey")
+
+	repo, ok := f.repos[repoKey]
+	if !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Repository '%s' not found", repoKey))
+		return
+	}
+
+	repo.ProjectKey = ""
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeJFrog) createToken(w http.ResponseWriter, r *http.Request) {
+	var request AccessTokenRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	if request.ProjectKey != "" {
+		if _, ok := f.projects[request.ProjectKey]; !ok {
+			accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Project '%s' not found", request.ProjectKey))
+			return
+		}
+	}
+
+	f.lastId++
+	tokenId := fmt.Sprintf("fake-token-%d", f.lastId)
+	f.tokens[tokenId] = fmt.Sprintf("fake-access-token-%d", f.lastId)
+
+	scope := request.Scope
+	if scope == "" {
+		scope = "applied-permissions/user"
+	}
+	writeJSON(w, http.StatusOK, AccessTokenResponse{
+		TokenId:     tokenId,
+		AccessToken: f.tokens[tokenId],
+		ExpiresIn:   request.ExpiresIn,
+		Scope:       scope,
+		TokenType:   "Bearer",
+	})
+}
+
+func (f *fakeJFrog) revokeToken(w http.ResponseWriter, r *http.Request) {
+	tokenId := r.PathValue("tokenId")
+	if _, ok := f.tokens[tokenId]; !ok {
+		accessError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Token '%s' not found", tokenId))
+		return
+	}
+
+	delete(f.tokens, tokenId)
+	w.WriteHeader(http.StatusOK)
+}
+
+// newFakeJFrogMeta returns the provider metadata of a client authenticated against a new fake JFrog Platform
+func newFakeJFrogMeta(t *testing.T) (*fakeJFrog, ProviderMetadata) {
+	fake := newFakeJFrog()
+	meta := newTestMeta(t, fake)
+	meta.Client.SetAuthToken(fakeAccessToken)
+
+	return fake, meta
+}
+
+func TestFakeJFrog_configure(t *testing.T) {
+	fake, meta := newFakeJFrogMeta(t)
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":          meta.Client.BaseURL,
+		"access_token": fakeAccessToken,
+	}))
+	if diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	for _, request := range []string{"GET /artifactory/api/system/license", "GET /artifactory/api/system/version"} {
+		if n := fake.requestCount(request); n != 1 {
+			t.Errorf("expected %s once, got %d", request, n)
+		}
+	}
+}
+
+func TestFakeJFrog_project(t *testing.T) {
+	fake, meta := newFakeJFrogMeta(t)
+	ctx := context.Background()
+
+	for _, url := range []string{"/artifactory/api/security/users/user1", "/artifactory/api/security/groups/group1"} {
+		if _, err := meta.Client.R().SetBody(map[string]string{}).Put(url); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, repoKey := range []string{"test-repo1", "test-repo2"} {
+		if _, err := meta.Client.R().SetBody(map[string]string{"key": repoKey, "rclass": "local"}).Put("/artifactory/api/repositories/" + repoKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := projectResource()
+	data := r.TestResourceData()
+	data.Set("key", "test")
+	data.Set("display_name", "Test")
+	data.Set("max_storage_bytes", -1)
+	data.Set("admin_privileges", []interface{}{
+		map[string]interface{}{"manage_members": true, "manage_resources": true, "index_resources": false},
+	})
+	data.Set("role", []interface{}{
+		map[string]interface{}{"name": "qa", "type": customRoleType, "environments": []interface{}{"DEV"}, "actions": []interface{}{"READ_REPOSITORY"}},
+	})
+	data.Set("member", []interface{}{
+		map[string]interface{}{"name": "user1", "roles": []interface{}{"qa"}},
+	})
+	data.Set("group", []interface{}{
+		map[string]interface{}{"name": "group1", "roles": []interface{}{"Developer"}},
+	})
+	data.Set("repos", []interface{}{"test-repo1", "test-repo2"})
+
+	if diags := r.CreateContext(ctx, data, meta); diags.HasError() {
+		t.Fatalf("expected no error on create, got %v", diags)
+	}
+	if data.Id() != "test" {
+		t.Errorf("expected ID test, got %q", data.Id())
+	}
+
+	if diags := r.ReadContext(ctx, data, meta); diags.HasError() {
+		t.Fatalf("expected no error on read, got %v", diags)
+	}
+	if members := data.Get("member").(*schema.Set).List(); len(members) != 1 || members[0].(map[string]interface{})["name"] != "user1" {
+		t.Errorf("expected member user1, got %v", members)
+	}
+	if roles := data.Get("role").(*schema.Set).List(); len(roles) != 1 || roles[0].(map[string]interface{})["name"] != "qa" {
+		t.Errorf("expected role qa, got %v", roles)
+	}
+	if repos := data.Get("repos").(*schema.Set).Len(); repos != 2 {
+		t.Errorf("expected 2 repos, got %d", repos)
+	}
+
+	// members can only be given the roles of the project
+	if _, err := meta.Client.R().SetBody(Member{Name: "user1", Roles: []string{"unknown"}}).Put("/access/api/v1/projects/test/users/user1"); err == nil {
+		t.Error("expected error for an unknown role")
+	}
+
+	if diags := r.DeleteContext(ctx, data, meta); diags.HasError() {
+		t.Fatalf("expected no error on delete, got %v", diags)
+	}
+	if _, ok := fake.projects["test"]; ok {
+		t.Error("expected project to be deleted")
+	}
+	for _, repo := range fake.repos {
+		if repo.ProjectKey != "" {
+			t.Errorf("expected %s to be unassigned, got %s", repo.Key, repo.ProjectKey)
+		}
+	}
+
+	if diags := r.ReadContext(ctx, data, meta); diags.HasError() || data.Id() != "" {
+		t.Errorf("expected project to be removed from state, got %q, %v", data.Id(), diags)
+	}
+}
+
+func TestFakeJFrog_roleAndEnvironment(t *testing.T) {
+	fake, meta := newFakeJFrogMeta(t)
+	ctx := context.Background()
+
+	if _, err := meta.Client.R().SetBody(Project{Key: "test", DisplayName: "Test", StorageQuota: -1}).Post(projectsUrl); err != nil {
+		t.Fatal(err)
+	}
+
+	env := projectEnvironmentResource()
+	envData := env.TestResourceData()
+	envData.Set("project_key", "test")
+	envData.Set("name", "staging")
+	if diags := env.CreateContext(ctx, envData, meta); diags.HasError() {
+		t.Fatalf("expected no error on environment create, got %v", diags)
+	}
+	if _, ok := fake.projects["test"].environments["test-staging"]; !ok {
+		t.Errorf("expected environment test-staging, got %v", fake.projects["test"].environments)
+	}
+
+	role := projectRoleResource()
+	roleData := role.TestResourceData()
+	roleData.Set("project_key", "test")
+	roleData.Set("name", "qa")
+	roleData.Set("type", customRoleType)
+	roleData.Set("environments", []interface{}{"staging"})
+	roleData.Set("actions", []interface{}{"READ_REPOSITORY"})
+	if diags := role.CreateContext(ctx, roleData, meta); diags.HasError() {
+		t.Fatalf("expected no error on role create, got %v", diags)
+	}
+	if envs := fake.projects["test"].roles["qa"].Environments; len(envs) != 1 || envs[0] != "test-staging" {
+		t.Errorf("expected role environments [test-staging], got %v", envs)
+	}
+
+	if diags := role.DeleteContext(ctx, roleData, meta); diags.HasError() {
+		t.Fatalf("expected no error on role delete, got %v", diags)
+	}
+	if diags := env.DeleteContext(ctx, envData, meta); diags.HasError() {
+		t.Fatalf("expected no error on environment delete, got %v", diags)
+	}
+	if len(fake.projects["test"].roles) != 0 || len(fake.projects["test"].environments) != 0 {
+		t.Errorf("expected no custom role and environment, got %v and %v", fake.projects["test"].roles, fake.projects["test"].environments)
+	}
+}
+
+func TestFakeJFrog_errors(t *testing.T) {
+	_, meta := newFakeJFrogMeta(t)
+
+	resp, err := meta.Client.R().SetPathParam("projectKey", "missing").Get(projectUrl)
+	err = newAPIError(resp, err)
+	var apiErr *APIError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &apiErr) {
+		t.Fatalf("expected not found API error, got %v", err)
+	}
+	if !apiErr.HasCode("NOT_FOUND") || apiErr.Messages() != "Project 'missing' not found" || !strings.HasPrefix(apiErr.RequestId, "fake-") {
+		t.Errorf("expected decoded error with request ID, got %+v", apiErr)
+	}
+
+	for _, key := range []string{"test1", "test2"} {
+		if _, err := meta.Client.R().SetBody(Project{Key: key, DisplayName: key}).Post(projectsUrl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err = meta.Client.R().SetBody(Project{Key: "test1", DisplayName: "other"}).Post(projectsUrl)
+	if err == nil || resp.StatusCode() != http.StatusConflict {
+		t.Errorf("expected conflict for an existing project, got %v", err)
+	}
+
+	if _, err := meta.Client.R().SetBody(map[string]string{"rclass": "local"}).Put("/artifactory/api/repositories/test-repo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := addRepos(context.Background(), "test1", []RepoKey{"test-repo"}, meta); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	resp, err = meta.Client.R().Put("/access/api/v1/projects/_/attach/repositories/test-repo/test2")
+	if err = newAPIError(resp, err); err == nil || !strings.Contains(err.Error(), "Repository is already assigned to another project") {
+		t.Errorf("expected error for a repository of another project, got %v", err)
+	}
+
+	resp, err = meta.Client.R().SetPathParam("projectKey", "test1").Delete(projectUrl)
+	if err == nil || !strings.Contains(resp.String(), "project containing resources can't be removed") {
+		t.Errorf("expected error for a project with repositories, got %v", err)
+	}
+
+	resp, err = meta.Client.R().SetAuthToken("invalid").Get(projectsUrl)
+	if err == nil || resp.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("expected unauthorized with an invalid token, got %v", err)
+	}
+}
+
+func TestFakeJFrog_memberPages(t *testing.T) {
+	defaultPageSize := pageSize
+	pageSize = 2
+	defer func() { pageSize = defaultPageSize }()
+
+	fake, meta := newFakeJFrogMeta(t)
+	fake.pageMembers = true
+
+	if _, err := meta.Client.R().SetBody(Project{Key: "test", DisplayName: "Test"}).Post(projectsUrl); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("user%d", i)
+		if _, err := meta.Client.R().SetBody(map[string]string{}).Put("/artifactory/api/security/users/" + name); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := meta.Client.R().SetBody(Member{Name: name, Roles: []string{"Developer"}}).Put("/access/api/v1/projects/test/users/" + name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	members, err := readMembers(context.Background(), "test", usersMembershipType, meta)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(members) != 5 {
+		t.Errorf("expected 5 members, got %v", members)
+	}
+	if n := fake.requestCount("GET /access/api/v1/projects/test/users"); n != 3 {
+		t.Errorf("expected 3 pages, got %d", n)
+	}
+}
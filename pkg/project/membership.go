@@ -3,10 +3,12 @@ package project
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jfrog/terraform-provider-shared/util"
+	"golang.org/x/exp/slices"
 )
 
 const projectMembershipsUrl = projectUrl + "/{membershipType}"
@@ -15,6 +17,21 @@ const projectMembershipUrl = projectMembershipsUrl + "/{memberName}"
 const usersMembershipType = "users"
 const groupssMembershipType = "groups"
 
+const (
+	membershipManagementAuthoritative = "authoritative"
+	membershipManagementAdditive      = "additive"
+)
+
+var membershipManagementModes = []string{membershipManagementAuthoritative, membershipManagementAdditive}
+
+// membershipAttribute returns the project resource attribute of the membership type
+func membershipAttribute(membershipType string) string {
+	if membershipType == groupssMembershipType {
+		return "group"
+	}
+	return "member"
+}
+
 // Use by both project user and project group, as they shared identical data structure
 type Member struct {
 	Name  string   `json:"name"`
@@ -29,34 +46,86 @@ func (a Member) Equals(b Equatable) bool {
 	return a.Id() == b.Id()
 }
 
+// Matches returns true if both members have the same name and roles, regardless of the roles order
+func (a Member) Matches(b Member) bool {
+	return a.Name == b.Name && hasSameElements(a.Roles, b.Roles)
+}
+
 // Use by both project user and project group, as they shared identical data structure
 type Membership struct {
 	Members []Member
+	// Unmanaged are the names of the members never deleted, e.g. copied from a template
+	Unmanaged []string
+	// Previous are the members in state before the change. In additive mode, they are deleted when they are
+	// no longer in Members.
+	Previous []Member
 }
 
 func getMembers(d *util.ResourceData, membershipKey string) []Member {
 	var members []Member
 
 	if v, ok := d.GetOk(membershipKey); ok {
-		projectMemberships := v.(*schema.Set).List()
-		if len(projectMemberships) == 0 {
-			return members
-		}
+		members = membersFromSet(v.(*schema.Set))
+	}
 
-		for _, projectMembership := range projectMemberships {
-			id := projectMembership.(map[string]interface{})
+	return members
+}
 
-			member := Member{
-				Name:  id["name"].(string),
-				Roles: util.CastToStringArr(id["roles"].(*schema.Set).List()),
-			}
-			members = append(members, member)
+func membersFromSet(set *schema.Set) []Member {
+	var members []Member
+
+	for _, projectMembership := range set.List() {
+		id := projectMembership.(map[string]interface{})
+
+		member := Member{
+			Name:  id["name"].(string),
+			Roles: util.CastToStringArr(id["roles"].(*schema.Set).List()),
 		}
+		members = append(members, member)
 	}
 
 	return members
 }
 
+// matchMemberNames returns the members renamed after the reference members with the same name ignoring case,
+// when caseInsensitive is set. It is used to send the names known by Artifactory, and to keep the names
+// from the configuration in state.
+func matchMemberNames(members []Member, reference []Member, caseInsensitive bool) []Member {
+	if !caseInsensitive {
+		return members
+	}
+
+	matched := make([]Member, 0, len(members))
+	for _, member := range members {
+		idx := slices.IndexFunc(reference, func(r Member) bool { return strings.EqualFold(r.Name, member.Name) })
+		if idx > -1 {
+			member.Name = reference[idx].Name
+		}
+		matched = append(matched, member)
+	}
+
+	return matched
+}
+
+// memberNamesEqual compares member names, ignoring case when caseInsensitive is set
+func memberNamesEqual(a, b string, caseInsensitive bool) bool {
+	if caseInsensitive {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// membersForState returns the project members to be stored in state, never nil so an empty list is stored too.
+// In additive mode only the members of the configuration are kept, so members added outside of Terraform
+// (e.g. by project admins) don't show up as drift. Names are expected to be matched with the configuration.
+func membersForState(mode string, projectMembers, terraformMembers []Member) []Member {
+	if mode != membershipManagementAdditive {
+		return SetFromSlice(projectMembers).Items()
+	}
+
+	return SetFromSlice(projectMembers).Intersection(SetFromSlice(terraformMembers)).Items()
+}
+
 var unpackMembers = func(data *schema.ResourceData, membershipKey string) Membership {
 	d := &util.ResourceData{ResourceData: data}
 	membership := Membership{
@@ -98,26 +167,24 @@ var readMembers = func(ctx context.Context, projectKey string, membershipType st
 		return nil, fmt.Errorf("invalid membershipType: %s", membershipType)
 	}
 
-	membership := Membership{}
-
-	_, err := m.(util.ProvderMetadata).Client.R().
-		SetPathParams(map[string]string{
-			"projectKey":     projectKey,
-			"membershipType": membershipType,
-		}).
-		SetResult(&membership).
-		Get(projectMembershipsUrl)
+	members, err := readAllPages[Member](ctx, m, projectMembershipsUrl, map[string]string{
+		"projectKey":     projectKey,
+		"membershipType": membershipType,
+	}, "members")
 	if err != nil {
 		return nil, err
 	}
 
-	tflog.Trace(ctx, fmt.Sprintf("readMembers: %+v\n", membership))
+	tflog.Trace(ctx, fmt.Sprintf("readMembers: %+v\n", members))
 
-	return membership.Members, nil
+	return members, nil
 }
 
-var updateMembers = func(ctx context.Context, projectKey string, membershipType string, terraformMembership Membership, m interface{}) ([]Member, error) {
-	tflog.Debug(ctx, "updateMembers")
+// updateMembers adds or updates the members of the configuration. In authoritative mode, the other members
+// of the project are deleted, except the unmanaged ones; in additive mode only the previous members removed
+// from the configuration are deleted, members added outside of Terraform are left untouched.
+var updateMembers = func(ctx context.Context, projectKey string, membershipType string, mode string, terraformMembership Membership, m interface{}) ([]Member, error) {
+	tflog.Debug(ctx, fmt.Sprintf("updateMembers: %s", mode))
 	tflog.Trace(ctx, fmt.Sprintf("terraformMembership.Members: %+v\n", terraformMembership.Members))
 
 	if membershipType != usersMembershipType && membershipType != groupssMembershipType {
@@ -126,29 +193,58 @@ var updateMembers = func(ctx context.Context, projectKey string, membershipType
 
 	projectMembers, err := readMembers(ctx, projectKey, membershipType, m)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch memberships for project: %s", err)
+		return nil, fmt.Errorf("failed to fetch memberships for project: %w", err)
 	}
 	tflog.Trace(ctx, fmt.Sprintf("projectMembers: %+v\n", projectMembers))
 
+	// Send the names known by Artifactory so members with a different case are not deleted and re-added
+	terraformMembership.Members = matchMemberNames(terraformMembership.Members, projectMembers, m.(ProviderMetadata).CaseInsensitiveMemberNames)
+
 	terraformMembersSet := SetFromSlice(terraformMembership.Members)
 	projectMembersSet := SetFromSlice(projectMembers)
 	membersToBeAdded := terraformMembersSet.Difference(projectMembersSet)
 	tflog.Trace(ctx, fmt.Sprintf("membersToBeAdded: %+v\n", membersToBeAdded))
-	membersToBeUpdated := terraformMembersSet.Intersection(projectMembersSet)
+	// Only members with different roles need to be updated
+	membersToBeUpdated := []Member{}
+	for _, member := range terraformMembersSet.Intersection(projectMembersSet).Items() {
+		idx := slices.IndexFunc(projectMembers, func(m Member) bool { return m.Equals(member) })
+		if !member.Matches(projectMembers[idx]) {
+			membersToBeUpdated = append(membersToBeUpdated, member)
+		}
+	}
 	tflog.Trace(ctx, fmt.Sprintf("membersToBeUpdated: %+v\n", membersToBeUpdated))
 	membersToBeDeleted := projectMembersSet.Difference(terraformMembersSet)
+	if mode == membershipManagementAdditive {
+		previousMembersSet := SetFromSlice(matchMemberNames(terraformMembership.Previous, projectMembers, m.(ProviderMetadata).CaseInsensitiveMemberNames))
+		membersToBeDeleted = membersToBeDeleted.Intersection(previousMembersSet)
+	}
+	membersToBeDeleted = membersToBeDeleted.filter(func(member Member) bool {
+		return !slices.Contains(terraformMembership.Unmanaged, member.Name)
+	})
 	tflog.Trace(ctx, fmt.Sprintf("membersToBeDeleted: %+v\n", membersToBeDeleted))
 
-	for _, member := range append(membersToBeAdded, membersToBeUpdated...) {
+	tflog.Info(ctx, fmt.Sprintf("updateMembers %s: %d to be added, %d to be updated, %d to be deleted, %d unchanged",
+		membershipType,
+		membersToBeAdded.Len(),
+		len(membersToBeUpdated),
+		membersToBeDeleted.Len(),
+		terraformMembersSet.Len()-membersToBeAdded.Len()-len(membersToBeUpdated),
+	))
+
+	for _, member := range append(membersToBeAdded.Items(), membersToBeUpdated...) {
 		err := updateMember(ctx, projectKey, membershipType, member, m)
 		if err != nil {
-			return nil, fmt.Errorf("failed to update members %s: %s", member, err)
+			return nil, &elementError{
+				Attribute: membershipAttribute(membershipType),
+				Name:      member.Name,
+				err:       fmt.Errorf("failed to update members %s: %w", member, err),
+			}
 		}
 	}
 
-	deleteErr := deleteMembers(ctx, projectKey, membershipType, membersToBeDeleted, m)
+	deleteErr := deleteMembers(ctx, projectKey, membershipType, membersToBeDeleted.Items(), m)
 	if deleteErr != nil {
-		return nil, fmt.Errorf("failed to delete members for project: %s", deleteErr)
+		return nil, fmt.Errorf("failed to delete members for project: %w", deleteErr)
 	}
 
 	return readMembers(ctx, projectKey, membershipType, m)
@@ -162,7 +258,7 @@ var updateMember = func(ctx context.Context, projectKey string, membershipType s
 		return fmt.Errorf("invalid membershipType: %s", membershipType)
 	}
 
-	_, err := m.(util.ProvderMetadata).Client.R().
+	resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 		SetPathParams(map[string]string{
 			"projectKey":     projectKey,
 			"membershipType": membershipType,
@@ -171,7 +267,7 @@ var updateMember = func(ctx context.Context, projectKey string, membershipType s
 		SetBody(member).
 		Put(projectMembershipUrl)
 
-	return err
+	return newAPIError(resp, err)
 }
 
 var deleteMembers = func(ctx context.Context, projectKey string, membershipType string, members []Member, m interface{}) error {
@@ -180,7 +276,7 @@ var deleteMembers = func(ctx context.Context, projectKey string, membershipType
 	for _, member := range members {
 		err := deleteMember(ctx, projectKey, membershipType, member, m)
 		if err != nil {
-			return fmt.Errorf("failed to delete %s %s: %s", membershipType, member, err)
+			return fmt.Errorf("failed to delete %s %s: %w", membershipType, member, err)
 		}
 	}
 
@@ -195,7 +291,7 @@ var deleteMember = func(ctx context.Context, projectKey string, membershipType s
 		return fmt.Errorf("invalid membershipType: %s", membershipType)
 	}
 
-	_, err := m.(util.ProvderMetadata).Client.R().
+	resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 		SetPathParams(map[string]string{
 			"projectKey":     projectKey,
 			"membershipType": membershipType,
@@ -203,7 +299,7 @@ var deleteMember = func(ctx context.Context, projectKey string, membershipType s
 		}).
 		Delete(projectMembershipUrl)
 	if err != nil {
-		return err
+		return newAPIError(resp, err)
 	}
 
 	return nil
@@ -1,8 +1,13 @@
 package project
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
@@ -10,6 +15,240 @@ import (
 	"github.com/jfrog/terraform-provider-shared/test"
 )
 
+func TestUpdateMembers_onlyChangedMembers(t *testing.T) {
+	projectMembers := Membership{}
+	for i := 0; i < 100; i++ {
+		projectMembers.Members = append(projectMembers.Members, Member{
+			Name:  fmt.Sprintf("user%d", i),
+			Roles: []string{"Developer", "Contributor"},
+		})
+	}
+
+	var lock sync.Mutex
+	calls := map[string][]string{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		calls[r.Method] = append(calls[r.Method], r.URL.Path)
+		lock.Unlock()
+
+		if r.Method == http.MethodGet && r.URL.Path == "/access/api/v1/projects/test/users" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(projectMembers)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// user0 is removed, user1 has a role change, user100 is added, others are unchanged with roles in different order
+	terraformMembership := Membership{}
+	for i := 1; i <= 100; i++ {
+		member := Member{
+			Name:  fmt.Sprintf("user%d", i),
+			Roles: []string{"Contributor", "Developer"},
+		}
+		if i == 1 {
+			member.Roles = []string{"Developer"}
+		}
+		terraformMembership.Members = append(terraformMembership.Members, member)
+	}
+
+	_, err := updateMembers(context.Background(), "test", usersMembershipType, membershipManagementAuthoritative, terraformMembership, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPuts := []string{"/access/api/v1/projects/test/users/user100", "/access/api/v1/projects/test/users/user1"}
+	if strings.Join(calls[http.MethodPut], ",") != strings.Join(expectedPuts, ",") {
+		t.Errorf("expected PUT %v, got %v", expectedPuts, calls[http.MethodPut])
+	}
+
+	expectedDeletes := []string{"/access/api/v1/projects/test/users/user0"}
+	if strings.Join(calls[http.MethodDelete], ",") != strings.Join(expectedDeletes, ",") {
+		t.Errorf("expected DELETE %v, got %v", expectedDeletes, calls[http.MethodDelete])
+	}
+}
+
+func TestUpdateMembers_additive(t *testing.T) {
+	// admin is added out-of-band by a project admin
+	projectMembers := Membership{
+		Members: []Member{
+			{Name: "admin", Roles: []string{"Project Admin"}},
+			{Name: "user1", Roles: []string{"Developer"}},
+		},
+	}
+
+	var lock sync.Mutex
+	calls := map[string][]string{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		calls[r.Method] = append(calls[r.Method], r.URL.Path)
+		lock.Unlock()
+
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(projectMembers)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	terraformMembership := Membership{
+		Members: []Member{
+			{Name: "user1", Roles: []string{"Contributor"}},
+			{Name: "user2", Roles: []string{"Developer"}},
+		},
+	}
+
+	members, err := updateMembers(context.Background(), "test", usersMembershipType, membershipManagementAdditive, terraformMembership, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPuts := []string{"/access/api/v1/projects/test/users/user2", "/access/api/v1/projects/test/users/user1"}
+	if strings.Join(calls[http.MethodPut], ",") != strings.Join(expectedPuts, ",") {
+		t.Errorf("expected PUT %v, got %v", expectedPuts, calls[http.MethodPut])
+	}
+	if len(calls[http.MethodDelete]) > 0 {
+		t.Errorf("expected no DELETE, got %v", calls[http.MethodDelete])
+	}
+
+	// The fake server doesn't record the changes, only user1 is both in the project and the configuration
+	state := membersForState(membershipManagementAdditive, members, terraformMembership.Members)
+	if len(state) != 1 || state[0].Name != "user1" {
+		t.Errorf("expected only user1 in state, got %v", state)
+	}
+
+	state = membersForState(membershipManagementAuthoritative, members, terraformMembership.Members)
+	if len(state) != 2 {
+		t.Errorf("expected all project members in state, got %v", state)
+	}
+}
+
+func TestUpdateMembers_additiveRemovedMember(t *testing.T) {
+	// admin is added out-of-band by a project admin, user2 was configured before
+	projectMembers := Membership{
+		Members: []Member{
+			{Name: "admin", Roles: []string{"Project Admin"}},
+			{Name: "user1", Roles: []string{"Developer"}},
+			{Name: "user2", Roles: []string{"Developer"}},
+		},
+	}
+
+	var lock sync.Mutex
+	calls := map[string][]string{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		calls[r.Method] = append(calls[r.Method], r.URL.Path)
+		lock.Unlock()
+
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(projectMembers)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	terraformMembership := Membership{
+		Members: []Member{
+			{Name: "user1", Roles: []string{"Developer"}},
+		},
+		Previous: []Member{
+			{Name: "user1", Roles: []string{"Developer"}},
+			{Name: "user2", Roles: []string{"Developer"}},
+		},
+	}
+
+	_, err := updateMembers(context.Background(), "test", usersMembershipType, membershipManagementAdditive, terraformMembership, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls[http.MethodPut]) > 0 {
+		t.Errorf("expected no PUT, got %v", calls[http.MethodPut])
+	}
+	expectedDeletes := []string{"/access/api/v1/projects/test/users/user2"}
+	if strings.Join(calls[http.MethodDelete], ",") != strings.Join(expectedDeletes, ",") {
+		t.Errorf("expected DELETE %v, got %v", expectedDeletes, calls[http.MethodDelete])
+	}
+}
+
+func TestUpdateMembers_caseInsensitiveNames(t *testing.T) {
+	projectMembers := Membership{
+		Members: []Member{
+			{Name: "jane.doe", Roles: []string{"Developer"}},
+			{Name: "john.doe", Roles: []string{"Developer"}},
+		},
+	}
+
+	for _, caseInsensitive := range []bool{true, false} {
+		var lock sync.Mutex
+		calls := map[string][]string{}
+
+		meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			calls[r.Method] = append(calls[r.Method], r.URL.Path)
+			lock.Unlock()
+
+			if r.Method == http.MethodGet {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(projectMembers)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		meta.CaseInsensitiveMemberNames = caseInsensitive
+
+		terraformMembership := Membership{
+			Members: []Member{
+				{Name: "Jane.Doe", Roles: []string{"Developer"}},
+				{Name: "John.Doe", Roles: []string{"Contributor"}},
+			},
+		}
+
+		_, err := updateMembers(context.Background(), "test", usersMembershipType, membershipManagementAuthoritative, terraformMembership, meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedPuts := []string{"/access/api/v1/projects/test/users/john.doe"}
+		expectedDeletes := []string{}
+		if !caseInsensitive {
+			expectedPuts = []string{"/access/api/v1/projects/test/users/Jane.Doe", "/access/api/v1/projects/test/users/John.Doe"}
+			expectedDeletes = []string{"/access/api/v1/projects/test/users/jane.doe", "/access/api/v1/projects/test/users/john.doe"}
+		}
+
+		if strings.Join(calls[http.MethodPut], ",") != strings.Join(expectedPuts, ",") {
+			t.Errorf("caseInsensitive %t: expected PUT %v, got %v", caseInsensitive, expectedPuts, calls[http.MethodPut])
+		}
+		if strings.Join(calls[http.MethodDelete], ",") != strings.Join(expectedDeletes, ",") {
+			t.Errorf("caseInsensitive %t: expected DELETE %v, got %v", caseInsensitive, expectedDeletes, calls[http.MethodDelete])
+		}
+	}
+}
+
+func TestMatchMemberNames(t *testing.T) {
+	members := []Member{{Name: "jane.doe"}, {Name: "other"}}
+	reference := []Member{{Name: "Jane.Doe"}}
+
+	matched := matchMemberNames(members, reference, true)
+	if matched[0].Name != "Jane.Doe" || matched[1].Name != "other" {
+		t.Errorf("unexpected names: %v", matched)
+	}
+
+	matched = matchMemberNames(members, reference, false)
+	if matched[0].Name != "jane.doe" {
+		t.Errorf("expected names to be unchanged, got %v", matched)
+	}
+}
+
 func TestAccProject_membership(t *testing.T) {
 	name := "tftestprojects" + randSeq(10)
 	resourceName := "project." + name
@@ -132,7 +371,7 @@ func TestAccProject_membership(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"use_project_role_resource"},
+				ImportStateVerifyIgnore: []string{"use_project_role_resource", "force_destroy", "rollback_on_failure", "repos_management", "membership_management"},
 			},
 			{
 				Config: noMemberConfig,
@@ -147,6 +386,129 @@ func TestAccProject_membership(t *testing.T) {
 	})
 }
 
+func TestAccProject_membershipAdditive(t *testing.T) {
+	name := "tftestprojects" + randSeq(10)
+	resourceName := "project." + name
+	projectKey := strings.ToLower(randSeq(6))
+
+	username1 := "user1"
+	email1 := username1 + "@tempurl.org"
+	username2 := "user2"
+	email2 := username2 + "@tempurl.org"
+
+	params := map[string]interface{}{
+		"name":        name,
+		"project_key": projectKey,
+		"username1":   username1,
+	}
+
+	config := test.ExecuteTemplate("TestAccProjectMemberAdditive", `
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			description = "test description"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+
+			membership_management = "additive"
+
+			member {
+				name = "{{ .username1 }}"
+				roles = ["Developer"]
+			}
+		}
+	`, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createTestUser(t, username1, email1)
+			createTestUser(t, username2, email2)
+		},
+		CheckDestroy: verifyDeleted(resourceName, func(id string, request *resty.Request) (*resty.Response, error) {
+			deleteTestUser(t, username1)
+			deleteTestUser(t, username2)
+			return verifyProject(id, request)
+		}),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "membership_management", "additive"),
+					resource.TestCheckResourceAttr(resourceName, "member.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "member.0.name", username1),
+				),
+			},
+			{
+				// Member added out-of-band by a project admin is neither reported as drift nor removed
+				PreConfig: func() {
+					restyClient := getTestResty(t)
+					_, err := restyClient.R().
+						SetPathParams(map[string]string{
+							"projectKey":     projectKey,
+							"membershipType": usersMembershipType,
+							"memberName":     username2,
+						}).
+						SetBody(Member{Name: username2, Roles: []string{"Contributor"}}).
+						Put(projectMembershipUrl)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "member.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "member.0.name", username1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProject_unknownMemberRole(t *testing.T) {
+	name := "tftestprojects" + randSeq(10)
+	projectKey := strings.ToLower(randSeq(6))
+
+	config := test.ExecuteTemplate("TestAccProjectUnknownMemberRole", `
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+
+			member {
+				name = "admin"
+				roles = ["Developr"]
+			}
+		}
+	`, map[string]interface{}{
+		"name":        name,
+		"project_key": projectKey,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`.*member admin: role Developr is not defined in project.*Did you mean Developer\?.*`),
+			},
+		},
+	})
+}
+
 func TestAccProject_group(t *testing.T) {
 	name := "tftestprojects" + randSeq(10)
 	resourceName := "project." + name
@@ -267,7 +629,7 @@ func TestAccProject_group(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"use_project_role_resource"},
+				ImportStateVerifyIgnore: []string{"use_project_role_resource", "force_destroy", "rollback_on_failure", "repos_management", "membership_management"},
 			},
 			{
 				Config: noGroupConfig,
@@ -0,0 +1,140 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// pageSize is the number of items requested per page of a collection. Servers may return smaller pages.
+var pageSize = 500
+
+// maxPages guards against a server returning a new cursor forever
+const maxPages = 10000
+
+// pageEnvelope is the paged form of a collection, with the items under a key of the object, e.g.
+// `{"members":[...],"cursor":"..."}` for cursor pagination, or `{"members":[...],"offset":0,"total":1234}`
+// for offset pagination
+type pageEnvelope struct {
+	Cursor string `json:"cursor"`
+	Offset *int   `json:"offset"`
+	Total  *int   `json:"total"`
+}
+
+// decodePage decodes the items of a page, either a JSON array or an object with the items under itemsKey.
+// The envelope is nil for arrays.
+func decodePage[T any](body []byte, itemsKey string) ([]T, *pageEnvelope, error) {
+	var items []T
+
+	if len(body) == 0 {
+		return items, nil, nil
+	}
+
+	if body[0] == '[' || bytes.Equal(body, []byte("null")) {
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, nil, err
+		}
+		return items, nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, nil, err
+	}
+	// the key is matched case-insensitively, as encoding/json does for struct fields
+	for key, raw := range fields {
+		if !strings.EqualFold(key, itemsKey) {
+			continue
+		}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", key, err)
+		}
+		break
+	}
+
+	envelope := pageEnvelope{}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, nil, err
+	}
+
+	return items, &envelope, nil
+}
+
+// readAllPages GETs all the items of a collection, page by page. Each request sets the `limit` query param,
+// and the next page is requested with the `cursor` returned by the server, or else with the `offset` of the
+// next item. The reading stops on a page with less than `limit` items, on an object page without a `cursor`
+// nor a `total`, or once the offset reaches the `total`.
+//
+// Servers not paging the collection return it whole on the first request, as a JSON array, which is the
+// complete result whatever its size. A page identical to the previous one means the server ignored the cursor
+// or offset, and the items read so far are the complete result.
+func readAllPages[T any](ctx context.Context, m interface{}, url string, pathParams map[string]string, itemsKey string) ([]T, error) {
+	all := []T{}
+	cursor := ""
+	offset := 0
+	var previous []byte
+
+	for page := 1; ; page++ {
+		if page > maxPages {
+			return nil, fmt.Errorf("failed to read %s: more than %d pages", url, maxPages)
+		}
+
+		req := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetPathParams(pathParams).
+			SetQueryParam("limit", strconv.Itoa(pageSize))
+		if cursor != "" {
+			req.SetQueryParam("cursor", cursor)
+		} else if offset > 0 {
+			req.SetQueryParam("offset", strconv.Itoa(offset))
+		}
+
+		resp, err := req.Get(url)
+		if err != nil {
+			return nil, newAPIError(resp, err)
+		}
+
+		body := bytes.TrimSpace(resp.Body())
+		if page > 1 && bytes.Equal(body, previous) {
+			tflog.Debug(ctx, fmt.Sprintf("readAllPages: page %d of %s is the same as the previous one, the server ignored the cursor or offset", page, url))
+			break
+		}
+		previous = body
+
+		items, envelope, err := decodePage[T](body, itemsKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode page %d of %s: %w", page, url, err)
+		}
+		all = append(all, items...)
+
+		tflog.Trace(ctx, fmt.Sprintf("readAllPages: page %d of %s, %d items", page, url, len(items)))
+
+		// an array is the whole collection, a short page is the last one, and a page larger than the limit
+		// means the server ignored it and returned the whole collection
+		if envelope == nil || len(items) != pageSize {
+			break
+		}
+
+		if envelope.Cursor != "" {
+			cursor = envelope.Cursor
+			continue
+		}
+
+		if envelope.Total == nil {
+			break
+		}
+		if envelope.Offset != nil {
+			offset = *envelope.Offset
+		}
+		offset += len(items)
+		if offset >= *envelope.Total {
+			break
+		}
+	}
+
+	return all, nil
+}
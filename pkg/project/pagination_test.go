@@ -0,0 +1,230 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// newPagingMeta serves total users, groups, roles and repos, paged in the different ways. It returns the
+// number of requests received.
+func newPagingMeta(t *testing.T, total int) (ProviderMetadata, *int) {
+	requests := 0
+
+	users := []Member{}
+	roles := []Role{}
+	repos := []map[string]string{}
+	for i := 0; i < total; i++ {
+		users = append(users, Member{Name: fmt.Sprintf("user%d", i), Roles: []string{"Developer"}})
+		roles = append(roles, Role{Name: fmt.Sprintf("role%d", i), Type: customRoleType})
+		repos = append(repos, map[string]string{"key": fmt.Sprintf("repo%d", i)})
+	}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+
+		query := r.URL.Query()
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil {
+			t.Errorf("expected limit query param, got %q", query.Get("limit"))
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if cursor := query.Get("cursor"); cursor != "" {
+			offset, _ = strconv.Atoi(cursor)
+		}
+
+		page := func(size int) (int, int) {
+			end := min(offset+size, total)
+			return min(offset, end), end
+		}
+
+		switch r.URL.Path {
+		// cursor pagination, the last page has no cursor
+		case "/access/api/v1/projects/test/users":
+			start, end := page(limit)
+			body := map[string]interface{}{"members": users[start:end]}
+			if end < total {
+				body["cursor"] = strconv.Itoa(end)
+			}
+			json.NewEncoder(w).Encode(body)
+		// offset pagination
+		case "/access/api/v1/projects/test/groups":
+			start, end := page(limit)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"members": users[start:end],
+				"offset":  start,
+				"total":   total,
+			})
+		// no pagination
+		case "/access/api/v1/projects/test/roles":
+			json.NewEncoder(w).Encode(roles)
+		case "/artifactory/api/repositories":
+			json.NewEncoder(w).Encode(repos)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return meta, &requests
+}
+
+func TestReadAllPages(t *testing.T) {
+	defaultPageSize := pageSize
+	pageSize = 5
+	defer func() { pageSize = defaultPageSize }()
+
+	for _, total := range []int{0, 1, 5, 12, 15} {
+		pagedRequests := max(1, (total+pageSize-1)/pageSize)
+
+		t.Run(strconv.Itoa(total), func(t *testing.T) {
+			meta, requests := newPagingMeta(t, total)
+			ctx := context.Background()
+
+			users, err := readMembers(ctx, "test", usersMembershipType, meta)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(users) != total || (total > 0 && users[total-1].Name != fmt.Sprintf("user%d", total-1)) {
+				t.Errorf("expected %d users, got %v", total, users)
+			}
+			if *requests != pagedRequests {
+				t.Errorf("expected %d requests for users, got %d", pagedRequests, *requests)
+			}
+
+			*requests = 0
+			groups, err := readMembers(ctx, "test", groupssMembershipType, meta)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(groups) != total {
+				t.Errorf("expected %d groups, got %v", total, groups)
+			}
+			if *requests != pagedRequests {
+				t.Errorf("expected %d requests for groups, got %d", pagedRequests, *requests)
+			}
+
+			*requests = 0
+			roles, err := readRoles(ctx, "test", meta)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(roles) != total {
+				t.Errorf("expected %d roles, got %v", total, roles)
+			}
+			if *requests != 1 {
+				t.Errorf("expected 1 request for roles, got %d", *requests)
+			}
+
+			*requests = 0
+			repoKeys, err := readRepos(ctx, "test", meta)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(repoKeys) != total || *requests != 1 {
+				t.Errorf("expected %d repos in 1 request, got %v in %d", total, repoKeys, *requests)
+			}
+		})
+	}
+}
+
+func TestReadAllPages_error(t *testing.T) {
+	meta, _ := newPagingMeta(t, 10)
+
+	names, err := readRoleNames(context.Background(), "missing", meta)
+	if err != nil || names != nil {
+		t.Errorf("expected no names and no error for a missing project, got %v, %v", names, err)
+	}
+
+	if _, err := readRoles(context.Background(), "missing", meta); err == nil {
+		t.Error("expected error for a missing project")
+	}
+}
+
+func TestReadAllPages_fullPage(t *testing.T) {
+	// exactly one page of the default size, from servers paging or not
+	meta, requests := newPagingMeta(t, pageSize)
+	ctx := context.Background()
+
+	users, err := readMembers(ctx, "test", usersMembershipType, meta)
+	if err != nil || len(users) != pageSize {
+		t.Errorf("expected %d users, got %d, %v", pageSize, len(users), err)
+	}
+	groups, err := readMembers(ctx, "test", groupssMembershipType, meta)
+	if err != nil || len(groups) != pageSize {
+		t.Errorf("expected %d groups, got %d, %v", pageSize, len(groups), err)
+	}
+	roles, err := readRoles(ctx, "test", meta)
+	if err != nil || len(roles) != pageSize {
+		t.Errorf("expected %d roles, got %d, %v", pageSize, len(roles), err)
+	}
+	repoKeys, err := readRepos(ctx, "test", meta)
+	if err != nil || len(repoKeys) != pageSize {
+		t.Errorf("expected %d repos, got %d, %v", pageSize, len(repoKeys), err)
+	}
+	if *requests != 4 {
+		t.Errorf("expected 1 request per listing, got %d", *requests)
+	}
+}
+
+func TestReadAllPages_repeatedPage(t *testing.T) {
+	defaultPageSize := pageSize
+	pageSize = 5
+	defer func() { pageSize = defaultPageSize }()
+
+	requests := 0
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		// the server ignores the cursor and returns the first page again
+		w.Write([]byte(`{"members":[{"name":"a"},{"name":"b"},{"name":"c"},{"name":"d"},{"name":"e"}],"cursor":"next"}`))
+	}))
+
+	users, err := readMembers(context.Background(), "test", usersMembershipType, meta)
+	if err != nil {
+		t.Fatalf("expected no error for a repeated page, got %v", err)
+	}
+	if len(users) != pageSize {
+		t.Errorf("expected the page once, got %v", users)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestDecodePage(t *testing.T) {
+	testCases := []struct {
+		body     string
+		expected int
+		envelope bool
+	}{
+		{``, 0, false},
+		{`null`, 0, false},
+		{`[]`, 0, false},
+		{`[{"name":"a"},{"name":"b"}]`, 2, false},
+		{`{"members":[{"name":"a"}],"cursor":"next"}`, 1, true},
+		{`{"Members":[{"name":"a"},{"name":"b"}]}`, 2, true},
+		{`{"other":[{"name":"a"}]}`, 0, true},
+	}
+
+	for _, tc := range testCases {
+		items, envelope, err := decodePage[Member]([]byte(tc.body), "members")
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", tc.body, err)
+			continue
+		}
+		if len(items) != tc.expected {
+			t.Errorf("%s: expected %d items, got %v", tc.body, tc.expected, items)
+		}
+		if (envelope != nil) != tc.envelope {
+			t.Errorf("%s: expected envelope %t, got %+v", tc.body, tc.envelope, envelope)
+		}
+	}
+
+	if _, _, err := decodePage[Member]([]byte(`{"members":"a"}`), "members"); err == nil {
+		t.Error("expected error for invalid items")
+	}
+}
@@ -17,6 +17,26 @@ var Version = "0.0.1"
 // needs to be exported so make file can update this
 var productId = "terraform-provider-project/" + Version
 
+// ProviderMetadata is passed to the resources as provider meta. It extends the shared metadata with the
+// settings of this provider.
+type ProviderMetadata struct {
+	util.ProvderMetadata
+	CaseInsensitiveMemberNames bool
+	RepositoryWorkers          int
+	// Cache holds the listings fetched during this run, it is installed as the transport of the client
+	Cache *responseCache
+}
+
+const defaultRepositoryWorkers = 10
+
+// repositoryWorkers returns the number of concurrent repository assignments, defaulting to defaultRepositoryWorkers
+func (m ProviderMetadata) repositoryWorkers() int {
+	if m.RepositoryWorkers < 1 {
+		return defaultRepositoryWorkers
+	}
+	return m.RepositoryWorkers
+}
+
 // Provider Projects provider that supports configuration via username+password or a token
 // Supported resources are repos, users, groups, replications, and permissions
 func Provider() *schema.Provider {
@@ -43,16 +63,36 @@ func Provider() *schema.Provider {
 				Default:     true,
 				Description: "Toggle for pre-flight checking of Artifactory Enterprise license. Default to `true`.",
 			},
+			"case_insensitive_member_names": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Match user and group names of project members case-insensitively, e.g. `Jane.Doe` in the configuration and `jane.doe` in Artifactory. Enable when Artifactory treats usernames case-insensitively to avoid state drift. Changing only the case of a configured name plans an update of the state, the members are not changed in Artifactory. Default to `false`.",
+			},
+			"repository_workers": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultRepositoryWorkers,
+				ValidateFunc: validation.IntBetween(1, 50),
+				Description:  "Number of repositories assigned to or unassigned from a project concurrently. Must be between 1 and 50. Default to `10`.",
+			},
 		},
 
-		ResourcesMap: util.AddTelemetry(
+		ResourcesMap: addTelemetry(
 			productId,
 			map[string]*schema.Resource{
-				"project":             projectResource(),
-				"project_environment": projectEnvironmentResource(),
-				"project_role":        projectRoleResource(),
+				"project":                        projectResource(),
+				"project_admin":                  projectAdminResource(),
+				"project_build_discard":          projectBuildDiscardResource(),
+				"project_environment":            projectEnvironmentResource(),
+				"project_role":                   projectRoleResource(),
+				"project_xray_indexed_resources": projectXrayIndexedResourcesResource(),
 			},
 		),
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"project_build_info_repository": projectBuildInfoRepositoryDataSource(),
+		},
 	}
 
 	p.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -101,8 +141,46 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 	featureUsage := fmt.Sprintf("Terraform/%s", terraformVersion)
 	util.SendUsage(ctx, restyBase, productId, featureUsage)
 
-	return util.ProvderMetadata{
-		Client:             restyBase,
-		ArtifactoryVersion: version,
+	// The provider is configured for each run (plan, apply, ...), so is the cache
+	cache := newResponseCache(restyBase.GetClient().Transport)
+	restyBase.SetTransport(cache)
+
+	return ProviderMetadata{
+		ProvderMetadata: util.ProvderMetadata{
+			Client:             restyBase,
+			ArtifactoryVersion: version,
+		},
+		CaseInsensitiveMemberNames: d.Get("case_insensitive_member_names").(bool),
+		RepositoryWorkers:          d.Get("repository_workers").(int),
+		Cache:                      cache,
 	}, nil
 }
+
+// addTelemetry is util.AddTelemetry for ProviderMetadata, as the shared one expects util.ProvderMetadata as meta
+func addTelemetry(productId string, resourceMap map[string]*schema.Resource) map[string]*schema.Resource {
+	var applyTelemetry = func(resource, verb string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			// best effort. Go routine it
+			featureUsage := fmt.Sprintf("Resource/%s/%s", resource, verb)
+			go util.SendUsage(ctx, meta.(ProviderMetadata).Client, productId, featureUsage)
+			return f(ctx, data, meta)
+		}
+	}
+
+	for name, skeema := range resourceMap {
+		if skeema.CreateContext != nil {
+			skeema.CreateContext = applyTelemetry(name, "CREATE", skeema.CreateContext)
+		}
+		if skeema.ReadContext != nil {
+			skeema.ReadContext = applyTelemetry(name, "READ", skeema.ReadContext)
+		}
+		if skeema.UpdateContext != nil {
+			skeema.UpdateContext = applyTelemetry(name, "UPDATE", skeema.UpdateContext)
+		}
+		if skeema.DeleteContext != nil {
+			skeema.DeleteContext = applyTelemetry(name, "DELETE", skeema.DeleteContext)
+		}
+	}
+
+	return resourceMap
+}
@@ -0,0 +1,156 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// FrameworkProvider serves the parts of the provider that SDKv2 can't, e.g. ephemeral resources.
+// It is muxed together with the SDKv2 Provider() in main.go, so its schema must stay identical.
+type FrameworkProvider struct{}
+
+type FrameworkProviderModel struct {
+	Url          types.String `tfsdk:"url"`
+	AccessToken  types.String `tfsdk:"access_token"`
+	CheckLicense types.Bool   `tfsdk:"check_license"`
+
+	CaseInsensitiveMemberNames types.Bool  `tfsdk:"case_insensitive_member_names"`
+	RepositoryWorkers          types.Int64 `tfsdk:"repository_workers"`
+}
+
+var _ provider.ProviderWithEphemeralResources = &FrameworkProvider{}
+
+func NewProvider() func() provider.Provider {
+	return func() provider.Provider {
+		return &FrameworkProvider{}
+	}
+}
+
+func (p *FrameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "project"
+	resp.Version = Version
+}
+
+func (p *FrameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of Artifactory. This can also be sourced from the `PROJECT_URL` or `JFROG_URL` environment variable. Default to 'http://localhost:8081' if not set.",
+			},
+			"access_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "This is a Bearer token that can be given to you by your admin under `Identity and Access`. This can also be sourced from the `PROJECT_ACCESS_TOKEN` or `JFROG_ACCESS_TOKEN` environment variable. Defauult to empty string if not set.",
+			},
+			"check_license": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Toggle for pre-flight checking of Artifactory Enterprise license. Default to `true`.",
+			},
+			"case_insensitive_member_names": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Match user and group names of project members case-insensitively, e.g. `Jane.Doe` in the configuration and `jane.doe` in Artifactory. Enable when Artifactory treats usernames case-insensitively to avoid state drift. Changing only the case of a configured name plans an update of the state, the members are not changed in Artifactory. Default to `false`.",
+			},
+			"repository_workers": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of repositories assigned to or unassigned from a project concurrently. Must be between 1 and 50. Default to `10`.",
+			},
+		},
+	}
+}
+
+func (p *FrameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	tflog.Info(ctx, fmt.Sprintf("Provider version: %s", Version))
+
+	var config FrameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// License check and usage reporting are done once by the SDKv2 provider, which receives the same configuration.
+	url := lookupEnvDefault(config.Url, "http://localhost:8081", "PROJECT_URL", "JFROG_URL")
+	accessToken := lookupEnvDefault(config.AccessToken, "", "PROJECT_ACCESS_TOKEN", "JFROG_ACCESS_TOKEN")
+	if accessToken == "" {
+		resp.Diagnostics.AddError("Missing access token", "you must supply an access token")
+		return
+	}
+
+	restyBase, err := client.Build(url, productId)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build client", err.Error())
+		return
+	}
+
+	restyBase, err = client.AddAuth(restyBase, "", accessToken)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to add authentication", err.Error())
+		return
+	}
+
+	cache := newResponseCache(restyBase.GetClient().Transport)
+	restyBase.SetTransport(cache)
+
+	meta := ProviderMetadata{
+		ProvderMetadata: util.ProvderMetadata{
+			Client: restyBase,
+		},
+		CaseInsensitiveMemberNames: config.CaseInsensitiveMemberNames.ValueBool(),
+		RepositoryWorkers:          int(config.RepositoryWorkers.ValueInt64()),
+		Cache:                      cache,
+	}
+
+	resp.DataSourceData = meta
+	resp.ResourceData = meta
+	resp.EphemeralResourceData = meta
+}
+
+func (p *FrameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *FrameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *FrameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewProjectAccessTokenEphemeralResource,
+	}
+}
+
+// MuxedProviders returns the SDKv2 and framework provider servers to be combined by tf5muxserver
+func MuxedProviders() []func() tfprotov5.ProviderServer {
+	return []func() tfprotov5.ProviderServer{
+		Provider().GRPCProvider,
+		providerserver.NewProtocol5(NewProvider()()),
+	}
+}
+
+// lookupEnvDefault mirrors schema.MultiEnvDefaultFunc for framework attributes
+func lookupEnvDefault(value types.String, defaultValue string, envs ...string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+
+	for _, env := range envs {
+		if v, ok := os.LookupEnv(env); ok && v != "" {
+			return v
+		}
+	}
+
+	return defaultValue
+}
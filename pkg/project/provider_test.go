@@ -2,14 +2,49 @@ package project
 
 import (
 	"context"
+	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-shared/client"
 )
 
+// fakeServerEnv points the acceptance tests at an in-process fake JFrog Platform (see newFakeJFrog) when
+// true, so they run without a licensed instance. PROJECT_URL and PROJECT_ACCESS_TOKEN are set to the fake.
+const fakeServerEnv = "PROJECT_FAKE_SERVER"
+
+func usingFakeServer() bool {
+	fake, _ := strconv.ParseBool(os.Getenv(fakeServerEnv))
+	return fake
+}
+
+// skipOnFakeServer skips the test when it runs against the fake JFrog Platform, which does not implement the
+// endpoints the test relies on
+func skipOnFakeServer(t *testing.T, reason string) {
+	if usingFakeServer() {
+		t.Skipf("not supported by the fake JFrog Platform: %s", reason)
+	}
+}
+
+func TestMain(m *testing.M) {
+	if !usingFakeServer() {
+		os.Exit(m.Run())
+	}
+
+	server := httptest.NewServer(newFakeJFrog())
+	os.Setenv("PROJECT_URL", server.URL)
+	os.Setenv("PROJECT_ACCESS_TOKEN", fakeAccessToken)
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -20,6 +55,29 @@ func TestProvider_impl(t *testing.T) {
 	var _ = Provider()
 }
 
+func TestMuxServer(t *testing.T) {
+	ctx := context.Background()
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, MuxedProviders()...)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := muxServer.ProviderServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("err: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	if _, ok := resp.EphemeralResourceSchemas["project_access_token"]; !ok {
+		t.Fatalf("ephemeral resource project_access_token not found in provider schema")
+	}
+}
+
 func getTestResty(t *testing.T) *resty.Client {
 	var ok bool
 	var projectUrl string
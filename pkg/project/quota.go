@@ -0,0 +1,82 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const storageInfoUrl = "/artifactory/api/storageinfo"
+
+// quotaUsageThreshold is the share of the quota above which the usage is considered near the quota
+const quotaUsageThreshold = 0.9
+
+type RepositorySummary struct {
+	RepoKey          string `json:"repoKey"`
+	UsedSpaceInBytes int64  `json:"usedSpaceInBytes"`
+}
+
+type StorageInfo struct {
+	RepositoriesSummaryList []RepositorySummary `json:"repositoriesSummaryList"`
+}
+
+// readStorageUsage returns the storage used by the repositories of the project, in bytes. It relies on the
+// Artifactory storage info, which is calculated periodically and may lag behind the actual usage.
+var readStorageUsage = func(ctx context.Context, projectKey string, m interface{}) (int64, error) {
+	tflog.Debug(ctx, "readStorageUsage")
+
+	repoKeys, err := readRepos(ctx, projectKey, m)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch repos for project: %w", err)
+	}
+
+	var storageInfo StorageInfo
+	resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+		SetResult(&storageInfo).
+		Get(storageInfoUrl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch storage info: %w", newAPIError(resp, err))
+	}
+
+	repos := SetFromSlice(repoKeys)
+	var usage int64
+	for _, summary := range storageInfo.RepositoriesSummaryList {
+		if repos.Contains(RepoKey(summary.RepoKey)) {
+			usage += summary.UsedSpaceInBytes
+		}
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("readStorageUsage: %d bytes in %d repos", usage, len(repoKeys)))
+
+	return usage, nil
+}
+
+// quotaLowered returns true if the new quota (in bytes, -1 for unlimited) is lower than the old one
+func quotaLowered(oldQuota, newQuota int64) bool {
+	if newQuota <= -1 {
+		return false
+	}
+
+	return oldQuota <= -1 || newQuota < oldQuota
+}
+
+// checkQuotaUsage returns an error if the quota is below the usage, or near it (the usage is above
+// quotaUsageThreshold of the quota)
+func checkQuotaUsage(quota, usage int64) error {
+	if quota <= -1 {
+		return nil
+	}
+
+	usageInGibibytes := float64(usage) / (1 << 30)
+
+	if usage > quota {
+		return fmt.Errorf("new storage quota %s is below the current usage of the project (%.2fGiB)", FormatStorage(quota), usageInGibibytes)
+	}
+
+	if float64(usage) > float64(quota)*quotaUsageThreshold {
+		return fmt.Errorf("new storage quota %s is near the current usage of the project (%.2fGiB, %.0f%% of the quota)", FormatStorage(quota), usageInGibibytes, float64(usage)/float64(quota)*100)
+	}
+
+	return nil
+}
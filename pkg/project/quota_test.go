@@ -0,0 +1,153 @@
+package project
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestReadStorageUsage(t *testing.T) {
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/artifactory/api/repositories":
+			w.Write([]byte(`[{"key":"test-repo1"},{"key":"test-repo2"}]`))
+		case storageInfoUrl:
+			w.Write([]byte(`{"repositoriesSummaryList":[
+				{"repoKey":"test-repo1","usedSpaceInBytes":1000},
+				{"repoKey":"test-repo2","usedSpaceInBytes":2000},
+				{"repoKey":"other-repo","usedSpaceInBytes":4000},
+				{"repoKey":"TOTAL","usedSpaceInBytes":7000}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	usage, err := readStorageUsage(context.Background(), "test", meta)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if usage != 3000 {
+		t.Errorf("expected usage of 3000 bytes, got %d", usage)
+	}
+}
+
+func TestQuotaLowered(t *testing.T) {
+	testCases := []struct {
+		oldQuota, newQuota int64
+		expected           bool
+	}{
+		{-1, 1 << 30, true},
+		{2 << 30, 1 << 30, true},
+		{1 << 30, 2 << 30, false},
+		{1 << 30, -1, false},
+		{-1, -1, false},
+	}
+
+	for _, tc := range testCases {
+		if lowered := quotaLowered(tc.oldQuota, tc.newQuota); lowered != tc.expected {
+			t.Errorf("quotaLowered(%d, %d): expected %t, got %t", tc.oldQuota, tc.newQuota, tc.expected, lowered)
+		}
+	}
+}
+
+func TestCheckQuotaUsage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		quota    int64
+		usage    int64
+		expected string
+	}{
+		{"unlimited", -1, 100 << 30, ""},
+		{"below usage", 10 << 30, 12 << 30, "new storage quota 10GiB is below the current usage of the project (12.00GiB)"},
+		{"near usage", 10 << 30, 19 << 29, "new storage quota 10GiB is near the current usage of the project (9.50GiB, 95% of the quota)"},
+		{"at threshold", 10 << 30, 9 << 30, ""},
+		{"well above usage", 500_000_000_000, 1 << 30, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkQuotaUsage(tc.quota, tc.usage)
+			if tc.expected == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestProject_quotaBelowUsage(t *testing.T) {
+	fake, meta := newFakeJFrogMeta(t)
+	ctx := context.Background()
+
+	adminPrivileges := []interface{}{
+		map[string]interface{}{"manage_members": true, "manage_resources": true, "index_resources": false},
+	}
+	r := projectResource()
+	data := r.TestResourceData()
+	data.Set("key", "test")
+	data.Set("display_name", "Test")
+	data.Set("admin_privileges", adminPrivileges)
+	data.Set("max_storage", "10GiB")
+	data.Set("max_storage_bytes", 10<<30)
+	if diags := r.CreateContext(ctx, data, meta); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	fake.lock.Lock()
+	fake.repos["test-repo"] = &fakeRepo{Key: "test-repo", Type: "LOCAL", PackageType: "generic", ProjectKey: "test", UsedSpace: 2 << 30}
+	fake.lock.Unlock()
+
+	// the storage quota is resolved from the raw configuration, which is only set on the state outside of Terraform
+	diffConfig := func(blockDeployments bool) (*terraform.InstanceState, *terraform.ResourceConfig) {
+		rawConfig, err := r.CoreConfigSchema().CoerceValue(cty.ObjectVal(map[string]cty.Value{
+			"key":                        cty.StringVal("test"),
+			"display_name":               cty.StringVal("Test"),
+			"max_storage":                cty.StringVal("1GiB"),
+			"block_deployments_on_limit": cty.BoolVal(blockDeployments),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := data.State()
+		state.RawConfig = rawConfig
+		return state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"key":                        "test",
+			"display_name":               "Test",
+			"admin_privileges":           adminPrivileges,
+			"max_storage":                "1GiB",
+			"block_deployments_on_limit": blockDeployments,
+		})
+	}
+
+	state, config := diffConfig(true)
+	_, err := r.Diff(ctx, state, config, meta)
+	if err == nil || !strings.Contains(err.Error(), "below the current usage") {
+		t.Errorf("expected the plan to fail with a hard limit, got %v", err)
+	}
+
+	state, config = diffConfig(false)
+	diff, err := r.Diff(ctx, state, config, meta)
+	if err != nil {
+		t.Fatalf("expected no error on plan with a soft limit, got %v", err)
+	}
+	_, diags := r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("expected no error on apply, got %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "below the current usage") {
+		t.Errorf("expected a warning on apply with a soft limit, got %v", diags)
+	}
+}
@@ -2,8 +2,10 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -11,6 +13,14 @@ import (
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
+const (
+	reposManagementAuthoritative = "authoritative"
+	reposManagementAdditive      = "additive"
+	reposManagementIgnore        = "ignore"
+)
+
+var reposManagementModes = []string{reposManagementAuthoritative, reposManagementAdditive, reposManagementIgnore}
+
 type RepoKey string
 
 func (r RepoKey) Id() string {
@@ -53,13 +63,9 @@ var readRepos = func(ctx context.Context, projectKey string, m interface{}) ([]R
 		Key string
 	}
 
-	artifactoryRepos := []ArtifactoryRepo{}
-
-	_, err := m.(util.ProvderMetadata).Client.R().
-		SetPathParam("projectKey", projectKey).
-		SetResult(&artifactoryRepos).
-		Get("/artifactory/api/repositories?project={projectKey}")
-
+	artifactoryRepos, err := readAllPages[ArtifactoryRepo](ctx, m, "/artifactory/api/repositories?project={projectKey}", map[string]string{
+		"projectKey": projectKey,
+	}, "repositories")
 	if err != nil {
 		return nil, err
 	}
@@ -75,13 +81,26 @@ var readRepos = func(ctx context.Context, projectKey string, m interface{}) ([]R
 	return repoKeys, nil
 }
 
-var updateRepos = func(ctx context.Context, projectKey string, terraformRepoKeys []RepoKey, m interface{}) ([]RepoKey, error) {
-	tflog.Debug(ctx, "updateRepos")
+// reposForState returns the project repos to be stored in state, never nil so an empty list is stored too.
+// In additive mode only the repos of the configuration are kept, so repos assigned outside of Terraform
+// don't show up as drift.
+func reposForState(mode string, projectRepoKeys, terraformRepoKeys []RepoKey) []RepoKey {
+	if mode != reposManagementAdditive {
+		return SetFromSlice(projectRepoKeys).Items()
+	}
+
+	return SetFromSlice(projectRepoKeys).Intersection(SetFromSlice(terraformRepoKeys)).Items()
+}
+
+// updateRepos assigns the repos of the configuration to the project. In authoritative mode, the other repos
+// of the project are unassigned; in additive mode they are left untouched.
+var updateRepos = func(ctx context.Context, projectKey string, mode string, terraformRepoKeys []RepoKey, m interface{}) ([]RepoKey, error) {
+	tflog.Debug(ctx, fmt.Sprintf("updateRepos: %s", mode))
 	tflog.Trace(ctx, fmt.Sprintf("terraformRepoKeys: %+v\n", terraformRepoKeys))
 
 	projectRepoKeys, err := readRepos(ctx, projectKey, m)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch repos for project: %s", err)
+		return nil, fmt.Errorf("failed to fetch repos for project: %w", err)
 	}
 	tflog.Trace(ctx, fmt.Sprintf("projectRepoKeys: %+v\n", projectRepoKeys))
 
@@ -92,43 +111,124 @@ var updateRepos = func(ctx context.Context, projectKey string, terraformRepoKeys
 	tflog.Trace(ctx, fmt.Sprintf("repoKeysToBeAdded: %+v\n", repoKeysToBeAdded))
 
 	repoKeysToBeDeleted := projectRepoKeysSet.Difference(terraformRepoKeysSet)
+	if mode == reposManagementAdditive {
+		repoKeysToBeDeleted = Set[RepoKey]{}
+	}
 	tflog.Trace(ctx, fmt.Sprintf("repoKeysToBeDeleted: %+v\n", repoKeysToBeDeleted))
 
-	addErr := addRepos(ctx, projectKey, repoKeysToBeAdded, m)
+	addErr := addRepos(ctx, projectKey, repoKeysToBeAdded.Items(), m)
 	if addErr != nil {
-		return nil, fmt.Errorf("failed to add repos for project: %s", addErr)
+		return nil, fmt.Errorf("failed to add repos for project: %w", addErr)
 	}
 
-	deleteErr := deleteRepos(ctx, projectKey, repoKeysToBeDeleted, m)
+	deleteErr := deleteRepos(ctx, projectKey, repoKeysToBeDeleted.Items(), m)
 	if deleteErr != nil {
-		return nil, fmt.Errorf("failed to delete repos for project: %s", deleteErr)
+		return nil, fmt.Errorf("failed to delete repos for project: %w", deleteErr)
 	}
 
 	return readRepos(ctx, projectKey, m)
 }
 
-var addRepos = func(ctx context.Context, projectKey string, repoKeys []RepoKey, m interface{}) error {
-	tflog.Debug(ctx, fmt.Sprintf("addRepos: %s", repoKeys))
+// repoProgressInterval is the number of processed repos between progress logs
+const repoProgressInterval = 50
+
+// forEachRepo calls do for each repo key with up to workers concurrent calls. It doesn't stop at the first
+// error: the errors of all failed calls are joined, in the order of the repo keys. Repos not processed
+// because the context is done are reported with the context error.
+var forEachRepo = func(ctx context.Context, operation string, repoKeys []RepoKey, workers int, do func(RepoKey) error) error {
+	if len(repoKeys) == 0 {
+		return nil
+	}
+	workers = max(1, min(workers, len(repoKeys)))
+
+	tflog.Info(ctx, fmt.Sprintf("%s %d repos with %d workers", operation, len(repoKeys), workers))
+
+	var lock sync.Mutex
+	errs := map[RepoKey]error{}
+	processed := 0
+
+	var wg sync.WaitGroup
+	keys := make(chan RepoKey)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				err := do(key)
+
+				lock.Lock()
+				processed++
+				if err != nil {
+					errs[key] = err
+				}
+				if processed%repoProgressInterval == 0 || processed == len(repoKeys) {
+					tflog.Info(ctx, fmt.Sprintf("%s repos: %d/%d processed, %d failed", operation, processed, len(repoKeys), len(errs)))
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	sent := 0
+send:
+	for _, key := range repoKeys {
+		// select picks randomly when both are ready, stop as soon as the context is done
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case keys <- key:
+			sent++
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(keys)
+	wg.Wait()
+
+	joined := []error{}
+	for _, key := range repoKeys {
+		if err, ok := errs[key]; ok {
+			joined = append(joined, err)
+		}
+	}
+	if sent < len(repoKeys) {
+		joined = append(joined, fmt.Errorf("%d repos not processed: %w", len(repoKeys)-sent, ctx.Err()))
+	}
+
+	return errors.Join(joined...)
+}
 
-	req := m.(util.ProvderMetadata).Client.R().
+// newRepoRequest returns a new request, retried on the transient errors of the repository assignment.
+// Each concurrent call needs its own request.
+var newRepoRequest = func(ctx context.Context, m interface{}) *resty.Request {
+	return m.(ProviderMetadata).Client.R().SetContext(ctx).
 		AddRetryCondition(retryOnSpecificMsgBody("A timeout occurred")).
 		AddRetryCondition(retryOnSpecificMsgBody("Web server is down")).
 		AddRetryCondition(retryOnSpecificMsgBody("Web server is returning an unknown error"))
+}
+
+var addRepos = func(ctx context.Context, projectKey string, repoKeys []RepoKey, m interface{}) error {
+	tflog.Debug(ctx, fmt.Sprintf("addRepos: %s", repoKeys))
 
-	for _, repoKey := range repoKeys {
-		err := addRepo(ctx, projectKey, repoKey, req)
+	return forEachRepo(ctx, "assigning", repoKeys, m.(ProviderMetadata).repositoryWorkers(), func(repoKey RepoKey) error {
+		err := addRepo(ctx, projectKey, repoKey, newRepoRequest(ctx, m))
 		if err != nil {
-			return fmt.Errorf("failed to add repo %s: %s", repoKey, err)
+			return &elementError{
+				Attribute: "repos",
+				Name:      string(repoKey),
+				err:       fmt.Errorf("failed to add repo %s: %w", repoKey, err),
+			}
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 var addRepo = func(ctx context.Context, projectKey string, repoKey RepoKey, req *resty.Request) error {
 	tflog.Debug(ctx, fmt.Sprintf("addRepo: %s", repoKey))
 
-	_, err := req.
+	resp, err := req.
 		SetPathParams(map[string]string{
 			"projectKey": projectKey,
 			"repoKey":    string(repoKey),
@@ -136,56 +236,35 @@ var addRepo = func(ctx context.Context, projectKey string, repoKey RepoKey, req
 		SetQueryParam("force", "true").
 		Put(projectsUrl + "/_/attach/repositories/{repoKey}/{projectKey}")
 
-	return err
+	return newAPIError(resp, err)
 }
 
 var deleteRepos = func(ctx context.Context, projectKey string, repoKeys []RepoKey, m interface{}) error {
 	tflog.Debug(ctx, fmt.Sprintf("deleteRepos: %s", repoKeys))
 
-	req := m.(util.ProvderMetadata).Client.R().
-		AddRetryCondition(retryOnSpecificMsgBody("A timeout occurred")).
-		AddRetryCondition(retryOnSpecificMsgBody("Web server is down")).
-		AddRetryCondition(retryOnSpecificMsgBody("Web server is returning an unknown error"))
-
-	for _, repoKey := range repoKeys {
-		err := deleteRepo(ctx, projectKey, repoKey, req)
+	return forEachRepo(ctx, "unassigning", repoKeys, m.(ProviderMetadata).repositoryWorkers(), func(repoKey RepoKey) error {
+		err := deleteRepo(ctx, projectKey, repoKey, newRepoRequest(ctx, m))
 		if err != nil {
-			return fmt.Errorf("failed to delete repo %s: %s", repoKey, err)
+			return fmt.Errorf("failed to delete repo %s: %w", repoKey, err)
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 var deleteRepo = func(ctx context.Context, projectKey string, repoKey RepoKey, req *resty.Request) error {
 	tflog.Debug(ctx, fmt.Sprintf("deleteRepo: %s", repoKey))
 
-	type Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-	}
-
-	type ErrorResponse struct {
-		Errors []Error `json:"errors"`
-	}
-
-	var errorResp ErrorResponse
-
 	resp, err := req.
 		SetPathParam("repoKey", string(repoKey)).
-		SetError(&errorResp).
 		Delete(projectsUrl + "/_/attach/repositories/{repoKey}")
 
 	// Ignore 404 NOT_FOUND error when unassigning repo from project
 	// Possible that repo was deleted out-of-band from TF
-	if resp.StatusCode() == http.StatusNotFound && len(errorResp.Errors) > 0 {
-		for _, error := range errorResp.Errors {
-			if error.Code == "NOT_FOUND" {
-				tflog.Warn(ctx, fmt.Sprintf("failed to unassign repo: %s", error.Message))
-				return nil
-			}
-		}
+	var apiErr *APIError
+	if errors.As(newAPIError(resp, err), &apiErr) && apiErr.StatusCode == http.StatusNotFound && apiErr.HasCode("NOT_FOUND") {
+		tflog.Warn(ctx, fmt.Sprintf("failed to unassign repo: %s", apiErr.Messages()))
+		return nil
 	}
 
-	return err
+	return newAPIError(resp, err)
 }
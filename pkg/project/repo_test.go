@@ -1,16 +1,168 @@
 package project
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/jfrog/terraform-provider-shared/test"
 )
 
+func TestUpdateRepos_modes(t *testing.T) {
+	testCases := []struct {
+		mode            string
+		expectedChanges []string
+		expectedState   []string
+	}{
+		{
+			mode: reposManagementAuthoritative,
+			expectedChanges: []string{
+				"PUT /access/api/v1/projects/_/attach/repositories/new-repo/test",
+				"DELETE /access/api/v1/projects/_/attach/repositories/oob-repo",
+			},
+			expectedState: []string{"test-repo", "oob-repo"},
+		},
+		{
+			mode: reposManagementAdditive,
+			expectedChanges: []string{
+				"PUT /access/api/v1/projects/_/attach/repositories/new-repo/test",
+			},
+			expectedState: []string{"test-repo"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			var lock sync.Mutex
+			changes := []string{}
+
+			meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.Method != http.MethodGet {
+					lock.Lock()
+					changes = append(changes, r.Method+" "+r.URL.Path)
+					lock.Unlock()
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+
+				w.Write([]byte(`[{"key":"test-repo"},{"key":"oob-repo"}]`))
+			}))
+
+			terraformRepoKeys := []RepoKey{"test-repo", "new-repo"}
+			projectRepoKeys, err := updateRepos(context.Background(), "test", tc.mode, terraformRepoKeys, meta)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if strings.Join(changes, ",") != strings.Join(tc.expectedChanges, ",") {
+				t.Errorf("expected changes %v, got %v", tc.expectedChanges, changes)
+			}
+
+			// The fake server doesn't record the changes, new-repo is not assigned when read back
+			state := []string{}
+			for _, key := range reposForState(tc.mode, projectRepoKeys, terraformRepoKeys) {
+				state = append(state, string(key))
+			}
+			if strings.Join(state, ",") != strings.Join(tc.expectedState, ",") {
+				t.Errorf("expected state %v, got %v", tc.expectedState, state)
+			}
+		})
+	}
+}
+
+func TestAddRepos_concurrent(t *testing.T) {
+	const numRepos = 100
+	const workers = 8
+
+	var lock sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	attached := map[string]bool{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		lock.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		lock.Lock()
+		inFlight--
+		attached[r.URL.Path] = true
+		lock.Unlock()
+
+		if strings.Contains(r.URL.Path, "repo-13/") || strings.Contains(r.URL.Path, "repo-42/") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"code":"BAD_REQUEST","message":"Repository is already assigned to another project"}]}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	meta.RepositoryWorkers = workers
+
+	repoKeys := []RepoKey{}
+	for i := 0; i < numRepos; i++ {
+		repoKeys = append(repoKeys, RepoKey(fmt.Sprintf("repo-%d", i)))
+	}
+
+	err := addRepos(context.Background(), "test", repoKeys, meta)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Failures don't stop the other repos, and are all reported in the order of the repo keys
+	if len(attached) != numRepos {
+		t.Errorf("expected %d repos to be attached, got %d", numRepos, len(attached))
+	}
+	if maxInFlight > workers {
+		t.Errorf("expected at most %d concurrent requests, got %d", workers, maxInFlight)
+	}
+	if !strings.Contains(err.Error(), "failed to add repo repo-13: ") || !strings.HasSuffix(err.Error(), "Repository is already assigned to another project") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if strings.Index(err.Error(), "repo-13") > strings.Index(err.Error(), "repo-42") {
+		t.Errorf("expected errors in the order of the repo keys: %v", err)
+	}
+
+	var elemErr *elementError
+	if !errors.As(err, &elemErr) || elemErr.Name != "repo-13" {
+		t.Errorf("expected element error of repo-13, got %v", elemErr)
+	}
+}
+
+func TestForEachRepo_contextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var lock sync.Mutex
+	processed := 0
+	err := forEachRepo(ctx, "assigning", []RepoKey{"repo-1", "repo-2", "repo-3", "repo-4"}, 1, func(repoKey RepoKey) error {
+		lock.Lock()
+		defer lock.Unlock()
+		processed++
+		cancel()
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error, got %v", err)
+	}
+	if processed == 4 {
+		t.Errorf("expected remaining repos not to be processed")
+	}
+}
+
 func TestAccProject_repo(t *testing.T) {
 	name := "tftestprojects" + randSeq(10)
 	resourceName := "project." + name
@@ -108,7 +260,7 @@ func TestAccProject_repo(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"use_project_role_resource"},
+				ImportStateVerifyIgnore: []string{"use_project_role_resource", "force_destroy", "rollback_on_failure", "repos_management", "membership_management"},
 			},
 			{
 				Config: noReposConfig,
@@ -233,7 +385,7 @@ func TestAccProject_repoAssignMultipleRepos(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"use_project_role_resource"},
+				ImportStateVerifyIgnore: []string{"use_project_role_resource", "force_destroy", "rollback_on_failure", "repos_management", "membership_management"},
 			},
 			{
 				Config: noReposConfig,
@@ -312,3 +464,87 @@ func TestAccProject_repoUnassignNonexistantRepo(t *testing.T) {
 		},
 	})
 }
+
+func TestAccProject_repoAdditive(t *testing.T) {
+	name := "tftestprojects" + randSeq(10)
+	resourceName := "project." + name
+	projectKey := strings.ToLower(randSeq(6))
+
+	repo1 := fmt.Sprintf("repo%d", test.RandomInt())
+	repo2 := fmt.Sprintf("repo%d", test.RandomInt())
+
+	params := map[string]interface{}{
+		"name":        name,
+		"project_key": projectKey,
+		"repo1":       repo1,
+		"repo2":       repo2,
+	}
+
+	config := test.ExecuteTemplate("TestAccProjectRepoAdditive", `
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			description = "test description"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+
+			repos            = ["{{ .repo1 }}"]
+			repos_management = "additive"
+
+			// repo assigned out-of-band must be unassigned before the project can be deleted
+			force_destroy = true
+		}
+	`, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createTestRepo(t, repo1)
+			createTestRepo(t, repo2)
+		},
+		CheckDestroy: verifyDeleted(resourceName, func(id string, request *resty.Request) (*resty.Response, error) {
+			deleteTestRepo(t, repo1)
+			deleteTestRepo(t, repo2)
+			return verifyProject(id, request)
+		}),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "repos_management", "additive"),
+					resource.TestCheckResourceAttr(resourceName, "repos.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "repos.0", repo1),
+				),
+			},
+			{
+				// Repo assigned out-of-band is neither reported as drift nor unassigned
+				PreConfig: func() {
+					restyClient := getTestResty(t)
+					_, err := restyClient.R().
+						SetPathParams(map[string]string{
+							"projectKey": projectKey,
+							"repoKey":    repo2,
+						}).
+						SetQueryParam("force", "true").
+						Put(projectsUrl + "/_/attach/repositories/{repoKey}/{projectKey}")
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "repos.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "repos.0", repo1),
+				),
+			},
+		},
+	})
+}
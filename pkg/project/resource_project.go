@@ -2,19 +2,23 @@ package project
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jfrog/terraform-provider-shared/util"
 	"github.com/jfrog/terraform-provider-shared/validator"
+	"golang.org/x/exp/slices"
 )
 
 type AdminPrivileges struct {
@@ -69,7 +73,8 @@ func projectResource() *schema.Resource {
 		},
 		"admin_privileges": {
 			Type:     schema.TypeSet,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"manage_members": {
@@ -89,38 +94,26 @@ func projectResource() *schema.Resource {
 					},
 				},
 			},
+			Description: "Required unless `source_project_key` is set.",
 		},
 		"max_storage_in_gibibytes": {
 			Type:     schema.TypeInt,
 			Optional: true,
-			Default:  -1,
+			Computed: true,
 			ValidateDiagFunc: validation.ToDiagFunc(
 				validation.Any(
 					int64Between(1, maxStorageInGibibytes),
 					validation.IntInSlice([]int{-1}),
 				),
 			),
-			DiffSuppressFunc: func(key, old, new string, d *schema.ResourceData) bool {
-				oldVal, err := strconv.Atoi(old)
-				if err != nil {
-					return false
-				}
-				newVal, err := strconv.Atoi(new)
-				if err != nil {
-					return false
-				}
-				// convert to bytes. The API says bytes, but the UI only allows GB (which makes more sense)
-				oldVal = oldVal * 1024 * 1024 * 1024
-				newVal = newVal * 1024 * 1024 * 1024
-				return newVal == oldVal
-			},
-			Description: "Storage quota in GiB. Must be 1 or larger. Set to -1 for unlimited storage. This is translated to binary bytes for Artifactory API. So for a 1TB quota, this should be set to 1024 (vs 1000) which will translate to 1099511627776 bytes for the API.",
+			ConflictsWith: []string{"max_storage"},
+			Description:   "Storage quota in GiB. Must be 1 or larger. Set to -1 for unlimited storage. This is translated to binary bytes for Artifactory API. So for a 1TB quota, this should be set to 1024 (vs 1000) which will translate to 1099511627776 bytes for the API. Quotas which are not a whole number of GiB (e.g. set in the UI in GB) are rounded down. Use `max_storage` instead to set the exact quota. Default to -1 when `max_storage` is not set.",
 		},
 		"block_deployments_on_limit": {
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     false,
-			Description: "Block deployment of artifacts if storage quota is exceeded.\n\n~>This setting only applies to self-hosted environment. See [Manage Storage Quotas](https://jfrog.com/help/r/jfrog-platform-administration-documentation/manage-storage-quotas).",
+			Description: "Block deployment of artifacts if storage quota is exceeded. When the storage quota is lowered, it is checked against the current usage of the project repositories: a quota below or near (usage above 90% of the quota) the usage fails the plan when this is true. Otherwise it is reported as a warning on apply, as Terraform doesn't show provider warnings in the plan.\n\n~>This setting only applies to self-hosted environment. See [Manage Storage Quotas](https://jfrog.com/help/r/jfrog-platform-administration-documentation/manage-storage-quotas).",
 		},
 		"email_notification": {
 			Type:        schema.TypeBool,
@@ -197,12 +190,15 @@ func projectResource() *schema.Resource {
 						Type:        schema.TypeSet,
 						Required:    true,
 						Elem:        &schema.Schema{Type: schema.TypeString},
-						Description: fmt.Sprintf("A repository can be available in different environments. Members with roles defined in the set environment will have access to the repository. List of pre-defined environments (%s)", strings.Join(validRoleEnvironments, ", ")),
+						Description: fmt.Sprintf("A repository can be available in different environments. Members with roles defined in the set environment will have access to the repository. List of pre-defined environments (%s) or custom environments of the project. Custom project environments can be referred to by their `project_environment` name, without the project key prefix.", strings.Join(validRoleEnvironments, ", ")),
 					},
 					"actions": {
-						Type:        schema.TypeSet,
-						Required:    true,
-						Elem:        &schema.Schema{Type: schema.TypeString},
+						Type:     schema.TypeSet,
+						Required: true,
+						Elem: &schema.Schema{
+							Type:             schema.TypeString,
+							ValidateDiagFunc: validation.ToDiagFunc(stringInSliceWithSuggestion(validRoleActions)),
+						},
 						Description: fmt.Sprintf("List of pre-defined actions (%s)", strings.Join(validRoleActions, ", ")),
 					},
 				},
@@ -216,7 +212,21 @@ func projectResource() *schema.Resource {
 				Type: schema.TypeString,
 			},
 			MinItems:    0,
-			Description: "(Optional) List of existing repo keys to be assigned to the project. **Note** We *strongly* recommend using this attribute to manage the list of repositories. If you wish to use the alternate method of setting `project_key` attribute in each `artifactory_*_repository` resource in the `artifactory` provider, you will need to use `lifecycle.ignore_changes` in the `project` resource to avoid state drift.\n\n```hcl\nlifecycle {\n\tignore_changes = [\n\t\trepos\n\t]\n}\n```",
+			Description: "(Optional) List of existing repo keys to be assigned to the project. **Note** We *strongly* recommend using this attribute to manage the list of repositories. If you wish to use the alternate method of setting `project_key` attribute in each `artifactory_*_repository` resource in the `artifactory` provider, set `repos_management` to `ignore` (or `additive` when both methods are used) to avoid state drift.",
+		},
+		"membership_management": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          membershipManagementAuthoritative,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(membershipManagementModes, false)),
+			Description:      fmt.Sprintf("How `member` and `group` are managed. `%s`: users and groups not configured are removed from the project. `%s`: configured users and groups are added or updated, other members of the project (e.g. added by project admins with `manage_members`) are left untouched and not reported as drift. Users and groups removed from the configuration are removed from the project. Default to `%s`.", membershipManagementAuthoritative, membershipManagementAdditive, membershipManagementAuthoritative),
+		},
+		"repos_management": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          reposManagementAuthoritative,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(reposManagementModes, false)),
+			Description:      fmt.Sprintf("How `repos` is managed. `%s`: repositories not in `repos` are unassigned from the project. `%s`: repositories in `repos` are assigned, other repositories of the project (e.g. assigned with `project_key` in the `artifactory` provider) are left untouched and not reported as drift. `%s`: repositories are neither read nor assigned. Default to `%s`.", reposManagementAuthoritative, reposManagementAdditive, reposManagementIgnore, reposManagementAuthoritative),
 		},
 	}
 
@@ -250,12 +260,15 @@ func projectResource() *schema.Resource {
 							Type:        schema.TypeSet,
 							Required:    true,
 							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: fmt.Sprintf("A repository can be available in different environments. Members with roles defined in the set environment will have access to the repository. List of pre-defined environments (%s)", strings.Join(validRoleEnvironments, ", ")),
+							Description: fmt.Sprintf("A repository can be available in different environments. Members with roles defined in the set environment will have access to the repository. List of pre-defined environments (%s) or custom environments of the project. Custom project environments can be referred to by their `project_environment` name, without the project key prefix.", strings.Join(validRoleEnvironments, ", ")),
 						},
 						"actions": {
-							Type:        schema.TypeSet,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(stringInSliceWithSuggestion(validRoleActions)),
+							},
 							Description: fmt.Sprintf("List of pre-defined actions (%s)", strings.Join(validRoleActions, ", ")),
 						},
 					},
@@ -263,6 +276,28 @@ func projectResource() *schema.Resource {
 				Description: "Project role. Element has one to one mapping with the [JFrog Project Roles API](https://www.jfrog.com/confluence/display/JFROG/Artifactory+REST+API#ArtifactoryRESTAPI-AddaNewRole)",
 				Deprecated:  "Replaced by `project_role` resource. This should not be used in combination with `project_role` resource. Use `use_project_role_resource` attribute to control which resource manages project roles.",
 			},
+			"source_project_key": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validator.ProjectKey,
+				// Only used on creation, changes afterwards have no effect
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+				Description: "Key of an existing project to be used as template. On creation only, its custom roles, project environments, members, groups and admin privileges are copied to the new project. Configured `admin_privileges`, `member`, `group` and `role` take precedence over the copied ones. `admin_privileges` is required unless this attribute is set. Copied members, groups and roles which are not configured are listed in `template_members`, `template_groups` and `template_roles`: they are not reported in `member`, `group` and `role`, and are never removed by this resource. After creation, changes of this attribute are ignored.",
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, destroying the project first removes everything from it, including resources added outside of Terraform: all repositories are unassigned, all members, groups and custom roles are removed, and project environments are deleted. Each step is reported as a warning. Must be applied before destroying. Default to false.",
+			},
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a failed project creation is rolled back: repositories assigned so far are unassigned and the project is deleted, so the next apply starts over instead of replacing a partially configured project. When false, the completed creation steps are reported in the error. Default to false.",
+			},
 			"use_project_role_resource": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -272,6 +307,46 @@ func projectResource() *schema.Resource {
 		},
 	)
 
+	var projectSchemaV3 = util.MergeMaps(
+		projectSchemaV2,
+		map[string]*schema.Schema{
+			"max_storage": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(storageSize),
+				DiffSuppressFunc: func(key, old, new string, d *schema.ResourceData) bool {
+					return storageEqual(old, new)
+				},
+				ConflictsWith: []string{"max_storage_in_gibibytes"},
+				Description:   "Storage quota with a decimal (`B`, `KB`, `MB`, `GB`, `TB`, `PB`) or binary (`KiB`, `MiB`, `GiB`, `TiB`, `PiB`) unit, e.g. `500GB` or `1.5TiB`, or `unlimited`. Units are case-insensitive. The size is converted exactly to bytes, rounded to the nearest byte (halves rounded up). Sizes resolving to the same number of bytes (e.g. `0.5TB` and `500GB`) are equivalent. When read from the server, the quota is expressed with the largest unit it is a whole multiple of. Conflicts with `max_storage_in_gibibytes`.",
+			},
+			"max_storage_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Exact storage quota in bytes, as set by `max_storage` or `max_storage_in_gibibytes`. -1 for unlimited storage.",
+			},
+			"template_members": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the users copied from `source_project_key` which were not configured in `member`. They are left untouched and not reported in `member`.",
+			},
+			"template_groups": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the groups copied from `source_project_key` which were not configured in `group`. They are left untouched and not reported in `group`.",
+			},
+			"template_roles": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the custom roles copied from `source_project_key` which were not configured in `role`. They are left untouched and not reported in `role`.",
+			},
+		},
+	)
+
 	var unpackProject = func(data *schema.ResourceData) (Project, Membership, Membership, []Role, []RepoKey, error) {
 		d := &util.ResourceData{ResourceData: data}
 
@@ -279,7 +354,7 @@ func projectResource() *schema.Resource {
 			Key:                    d.GetString("key", false),
 			DisplayName:            d.GetString("display_name", false),
 			Description:            d.GetString("description", false),
-			StorageQuota:           GibibytesToBytes(d.GetInt("max_storage_in_gibibytes", false)),
+			StorageQuota:           int64(d.GetInt("max_storage_bytes", false)),
 			SoftLimit:              !d.GetBool("block_deployments_on_limit", false),
 			QuotaEmailNotification: d.GetBool("email_notification", false),
 		}
@@ -316,6 +391,11 @@ func projectResource() *schema.Resource {
 		setValue("display_name", project.DisplayName)
 		setValue("description", project.Description)
 		setValue("max_storage_in_gibibytes", BytesToGibibytes(project.StorageQuota))
+		setValue("max_storage_bytes", project.StorageQuota)
+		// Keep the unit from state (e.g. `0.5TB`) when it is still the same quota
+		if bytes, err := ParseStorage(d.Get("max_storage").(string)); err != nil || bytes != project.StorageQuota {
+			setValue("max_storage", FormatStorage(project.StorageQuota))
+		}
 		setValue("block_deployments_on_limit", !project.SoftLimit)
 		setValue("email_notification", project.QuotaEmailNotification)
 		errors = setValue("admin_privileges", []interface{}{
@@ -326,11 +406,11 @@ func projectResource() *schema.Resource {
 			},
 		})
 
-		if len(users) > 0 {
+		if users != nil {
 			errors = packMembers(ctx, d, "member", users)
 		}
 
-		if len(groups) > 0 {
+		if groups != nil {
 			errors = packMembers(ctx, d, "group", groups)
 		}
 
@@ -338,7 +418,8 @@ func projectResource() *schema.Resource {
 			errors = packRoles(ctx, d, roles)
 		}
 
-		if len(repos) > 0 {
+		// nil repos (e.g. `repos_management` is ignore) are left as they are in state
+		if repos != nil {
 			errors = packRepos(ctx, d, repos)
 		}
 
@@ -349,44 +430,109 @@ func projectResource() *schema.Resource {
 		return nil
 	}
 
+	// projectNotFound removes the project from state so Terraform plans to re-create it
+	var projectNotFound = func(ctx context.Context, data *schema.ResourceData, err error) diag.Diagnostics {
+		tflog.Warn(ctx, fmt.Sprintf("project %s not found, removing from state: %s", data.Id(), err))
+
+		projectKey := data.Id()
+		data.SetId("")
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Project not found",
+			Detail:   fmt.Sprintf("Project %s was not found, it may have been deleted outside of Terraform. It has been removed from state.", projectKey),
+		}}
+	}
+
 	var readProject = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		project := Project{}
 
-		_, err := m.(util.ProvderMetadata).Client.R().
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParam("projectKey", data.Id()).
 			SetResult(&project).
 			Get(projectUrl)
 		if err != nil {
-			return diag.FromErr(err)
+			if errors.Is(newAPIError(resp, err), ErrNotFound) {
+				return projectNotFound(ctx, data, err)
+			}
+			return errorDiagnostics(data, withStep("reading project", newAPIError(resp, err)))
 		}
 
 		users, err := readMembers(ctx, data.Id(), usersMembershipType, m)
 		if err != nil {
-			return diag.FromErr(err)
+			if errors.Is(err, ErrNotFound) {
+				return projectNotFound(ctx, data, err)
+			}
+			return errorDiagnostics(data, withStep("reading members", err))
 		}
 
 		groups, err := readMembers(ctx, data.Id(), groupssMembershipType, m)
 		if err != nil {
-			return diag.FromErr(err)
+			if errors.Is(err, ErrNotFound) {
+				return projectNotFound(ctx, data, err)
+			}
+			return errorDiagnostics(data, withStep("reading groups", err))
 		}
 
+		// Keep the names from the configuration for members with a different case in Artifactory
+		caseInsensitive := m.(ProviderMetadata).CaseInsensitiveMemberNames
+		configuredUsers := unpackMembers(data, "member").Members
+		configuredGroups := unpackMembers(data, "group").Members
+		users = matchMemberNames(users, configuredUsers, caseInsensitive)
+		groups = matchMemberNames(groups, configuredGroups, caseInsensitive)
+
+		membershipManagement := data.Get("membership_management").(string)
+		users = membersForState(membershipManagement, users, configuredUsers)
+		groups = membersForState(membershipManagement, groups, configuredGroups)
+
+		// Members and roles copied from the template are not managed by the configuration
+		users = withoutCopied(users, templateNames(data, "template_members"), configuredUsers)
+		groups = withoutCopied(groups, templateNames(data, "template_groups"), configuredGroups)
+
 		roles := []Role{}
 		useProjectRoleResource := data.Get("use_project_role_resource").(bool)
 		if !useProjectRoleResource {
 			roles, err = readRoles(ctx, data.Id(), m)
 			if err != nil {
-				return diag.FromErr(err)
+				if errors.Is(err, ErrNotFound) {
+					return projectNotFound(ctx, data, err)
+				}
+				return errorDiagnostics(data, withStep("reading roles", err))
 			}
+			configuredRoles := unpackRoles(data)
+			roles = rolesFromServer(data.Id(), withoutCopied(roles, templateNames(data, "template_roles"), configuredRoles), configuredRoles)
 		}
 
-		repos, err := readRepos(ctx, data.Id(), m)
-		if err != nil {
-			return diag.FromErr(err)
+		var repos []RepoKey
+		reposManagement := data.Get("repos_management").(string)
+		if reposManagement != reposManagementIgnore {
+			repos, err = readRepos(ctx, data.Id(), m)
+			if err != nil {
+				if errors.Is(err, ErrNotFound) {
+					return projectNotFound(ctx, data, err)
+				}
+				return errorDiagnostics(data, withStep("reading repositories", err))
+			}
+			repos = reposForState(reposManagement, repos, unpackRepos(data))
 		}
 
 		return packProject(ctx, data, project, users, groups, roles, repos)
 	}
 
+	var removeProject = func(ctx context.Context, projectKey string, m interface{}) (*resty.Response, error) {
+		req := m.(ProviderMetadata).Client.R().SetContext(ctx)
+		req.AddRetryCondition(
+			func(r *resty.Response, _ error) bool {
+				return r.StatusCode() == http.StatusBadRequest &&
+					strings.Contains(r.String(), "project containing resources can't be removed")
+			},
+		)
+
+		return req.
+			SetPathParam("projectKey", projectKey).
+			Delete(projectUrl)
+	}
+
 	var createProject = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		tflog.Debug(ctx, "createProject")
 		tflog.Trace(ctx, fmt.Sprintf("%+v\n", data))
@@ -396,35 +542,130 @@ func projectResource() *schema.Resource {
 			return diag.FromErr(err)
 		}
 
-		_, err = m.(util.ProvderMetadata).Client.R().SetBody(project).Post(projectsUrl)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		data.SetId(project.Id())
-
-		// Role should be updated first before members or groups as they may depend on roles defined by the users
+		// The template contents are only applied here, the project is managed by its configuration afterwards
+		var template ProjectTemplate
 		useProjectRoleResource := data.Get("use_project_role_resource").(bool)
-		if !useProjectRoleResource {
-			_, err = updateRoles(ctx, data.Id(), roles, m)
+		sourceProjectKey := data.Get("source_project_key").(string)
+		if sourceProjectKey != "" {
+			template, err = readProjectTemplate(ctx, sourceProjectKey, m)
 			if err != nil {
 				return diag.FromErr(err)
 			}
+
+			if _, ok := data.GetOk("admin_privileges"); !ok {
+				project.AdminPrivileges = template.AdminPrivileges
+			}
+
+			// The copied values are recorded apart, so they don't show up as drift of the configured ones
+			templateRoleNames := []string{}
+			if !useProjectRoleResource {
+				templateRoleNames = copiedNames(template.Roles, roles)
+				roles = templateRoles(project.Key, template, roles)
+			}
+			setValue := util.MkLens(data)
+			setValue("template_members", copiedNames(template.Users, users.Members))
+			setValue("template_groups", copiedNames(template.Groups, groups.Members))
+			if errs := setValue("template_roles", templateRoleNames); len(errs) > 0 {
+				return diag.Errorf("failed to pack project template %q", errs)
+			}
+
+			users = templateMembers(template.Users, users)
+			groups = templateMembers(template.Groups, groups)
 		}
 
-		_, err = updateMembers(ctx, data.Id(), usersMembershipType, users, m)
-		if err != nil {
-			return diag.FromErr(err)
+		// Roles, members and environments are removed with the project, so only the project and
+		// the repos assignment (which prevents the project deletion) need to be undone on rollback.
+		steps := []createStep{
+			{
+				Name: "project",
+				Do: func() error {
+					resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).SetBody(project).Post(projectsUrl)
+					if err != nil {
+						return newAPIError(resp, err)
+					}
+
+					data.SetId(project.Id())
+					return nil
+				},
+				Undo: func() error {
+					_, err := removeProject(ctx, project.Key, m)
+					return err
+				},
+			},
 		}
 
-		_, err = updateMembers(ctx, data.Id(), groupssMembershipType, groups, m)
-		if err != nil {
-			return diag.FromErr(err)
+		if sourceProjectKey != "" {
+			// Environments should be created first as they may be referenced by the template roles
+			steps = append(steps, createStep{
+				Name: "environments",
+				Do: func() error {
+					return createTemplateEnvironments(ctx, project.Key, template, m)
+				},
+			})
 		}
 
-		_, err = updateRepos(ctx, data.Id(), repos, m)
-		if err != nil {
-			return diag.FromErr(err)
+		// Role should be updated first before members or groups as they may depend on roles defined by the users
+		if !useProjectRoleResource {
+			steps = append(steps, createStep{
+				Name: "roles",
+				Do: func() error {
+					_, err := updateRoles(ctx, project.Key, roles, nil, m)
+					return err
+				},
+			})
+		}
+
+		membershipManagement := data.Get("membership_management").(string)
+		steps = append(steps,
+			createStep{
+				Name: "members",
+				Do: func() error {
+					_, err := updateMembers(ctx, project.Key, usersMembershipType, membershipManagement, users, m)
+					return err
+				},
+			},
+			createStep{
+				Name: "groups",
+				Do: func() error {
+					_, err := updateMembers(ctx, project.Key, groupssMembershipType, membershipManagement, groups, m)
+					return err
+				},
+			},
+		)
+
+		reposManagement := data.Get("repos_management").(string)
+		if reposManagement != reposManagementIgnore {
+			steps = append(steps, createStep{
+				Name: "repos",
+				Do: func() error {
+					_, err := updateRepos(ctx, project.Key, reposManagement, repos, m)
+					return err
+				},
+				// Only the repos assigned to the new project, by this step, are unassigned. The others may
+				// have failed to be assigned because they belong to another project.
+				UndoFailed: true,
+				Undo: func() error {
+					projectRepos, err := readRepos(ctx, project.Key, m)
+					if err != nil {
+						return err
+					}
+
+					attached := SetFromSlice(projectRepos).Intersection(SetFromSlice(repos))
+					return deleteRepos(ctx, project.Key, attached.Items(), m)
+				},
+			})
+		}
+
+		rollbackOnFailure := data.Get("rollback_on_failure").(bool)
+		result := runCreateSteps(ctx, steps, rollbackOnFailure)
+		if result.Err != nil {
+			// Project is gone, leave nothing in state so the next apply starts over, even if other steps
+			// failed to roll back
+			if slices.Contains(result.RolledBack, "project") {
+				data.SetId("")
+			}
+
+			return result.Diagnostics(data)
 		}
 
 		return readProject(ctx, data, m)
@@ -439,12 +680,27 @@ func projectResource() *schema.Resource {
 			return diag.FromErr(err)
 		}
 
-		_, err = m.(util.ProvderMetadata).Client.R().
+		var diags diag.Diagnostics
+		oldQuota, newQuota := data.GetChange("max_storage_bytes")
+		if quotaLowered(int64(oldQuota.(int)), int64(newQuota.(int))) {
+			usage, err := readStorageUsage(ctx, data.Id(), m)
+			if err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("unable to check the storage quota against the current usage: %s", err))
+			} else if err := checkQuotaUsage(int64(newQuota.(int)), usage); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Storage quota lowered",
+					Detail:   fmt.Sprintf("%s. Deployments may fail once the quota is reached.", err),
+				})
+			}
+		}
+
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParam("projectKey", data.Id()).
 			SetBody(project).
 			Put(projectUrl)
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("updating project", newAPIError(resp, err)))
 		}
 
 		data.SetId(project.Id())
@@ -452,61 +708,329 @@ func projectResource() *schema.Resource {
 		// Role should be updated first before members or groups as they may depend on roles defined by the users
 		useProjectRoleResource := data.Get("use_project_role_resource").(bool)
 		if !useProjectRoleResource {
-			_, err = updateRoles(ctx, data.Id(), roles, m)
+			_, err = updateRoles(ctx, data.Id(), roles, templateNames(data, "template_roles"), m)
 			if err != nil {
-				return diag.FromErr(err)
+				return errorDiagnostics(data, withStep("updating roles", err))
 			}
 		}
 
-		_, err = updateMembers(ctx, data.Id(), usersMembershipType, users, m)
+		users.Unmanaged = templateNames(data, "template_members")
+		groups.Unmanaged = templateNames(data, "template_groups")
+		oldUsers, _ := data.GetChange("member")
+		users.Previous = membersFromSet(oldUsers.(*schema.Set))
+		oldGroups, _ := data.GetChange("group")
+		groups.Previous = membersFromSet(oldGroups.(*schema.Set))
+
+		membershipManagement := data.Get("membership_management").(string)
+		_, err = updateMembers(ctx, data.Id(), usersMembershipType, membershipManagement, users, m)
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("updating members", err))
 		}
 
-		_, err = updateMembers(ctx, data.Id(), groupssMembershipType, groups, m)
+		_, err = updateMembers(ctx, data.Id(), groupssMembershipType, membershipManagement, groups, m)
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("updating groups", err))
+		}
+
+		reposManagement := data.Get("repos_management").(string)
+		if reposManagement != reposManagementIgnore {
+			_, err = updateRepos(ctx, data.Id(), reposManagement, repos, m)
+			if err != nil {
+				return errorDiagnostics(data, withStep("updating repositories", err))
+			}
+		}
+
+		return append(diags, readProject(ctx, data, m)...)
+	}
+
+	// forceDestroyProject removes everything from the project, including resources added outside of Terraform,
+	// so the project can be deleted. Each step is reported with a warning.
+	var forceDestroyProject = func(ctx context.Context, projectKey string, m interface{}) diag.Diagnostics {
+		tflog.Debug(ctx, "forceDestroyProject")
+
+		var diags diag.Diagnostics
+		var report = func(step string, names []string) {
+			removed := "none"
+			if len(names) > 0 {
+				removed = strings.Join(names, ", ")
+			}
+
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("force_destroy: %s of project %s", step, projectKey),
+				Detail:   fmt.Sprintf("%d removed: %s", len(names), removed),
+			})
+		}
+		var fail = func(step string, err error) diag.Diagnostics {
+			err = withStep(fmt.Sprintf("removing %s", step), err)
+			return append(diags, errorDiagnostics(nil, fmt.Errorf("force_destroy: failed to remove %s of project %s: %w", step, projectKey, err))...)
 		}
 
-		_, err = updateRepos(ctx, data.Id(), repos, m)
+		repos, err := readRepos(ctx, projectKey, m)
+		if err == nil {
+			err = deleteRepos(ctx, projectKey, repos, m)
+		}
 		if err != nil {
-			return diag.FromErr(err)
+			return fail("repositories", err)
+		}
+		repoKeys := []string{}
+		for _, repo := range repos {
+			repoKeys = append(repoKeys, string(repo))
 		}
+		report("repositories", repoKeys)
 
-		return readProject(ctx, data, m)
+		for _, membershipType := range []string{usersMembershipType, groupssMembershipType} {
+			members, err := readMembers(ctx, projectKey, membershipType, m)
+			if err == nil {
+				err = deleteMembers(ctx, projectKey, membershipType, members, m)
+			}
+			if err != nil {
+				return fail(membershipType, err)
+			}
+			names := []string{}
+			for _, member := range members {
+				names = append(names, member.Name)
+			}
+			report(membershipType, names)
+		}
+
+		// Roles are removed after members as members refer to them
+		roles, err := readRoles(ctx, projectKey, m)
+		if err == nil {
+			err = deleteRoles(ctx, projectKey, roles, m)
+		}
+		if err != nil {
+			return fail("custom roles", err)
+		}
+		roleNames := []string{}
+		for _, role := range roles {
+			roleNames = append(roleNames, role.Name)
+		}
+		report("custom roles", roleNames)
+
+		// Environments are removed after roles as roles refer to them. Global environments are left untouched.
+		environments, err := readEnvironmentNames(ctx, projectKey, m)
+		if err != nil {
+			return fail("environments", err)
+		}
+		envNames := []string{}
+		for _, env := range environments {
+			if !strings.HasPrefix(env, projectKey+"-") {
+				continue
+			}
+
+			resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+				SetPathParams(map[string]string{
+					"projectKey":      projectKey,
+					"environmentName": env,
+				}).
+				Delete(projectEnvironmentUrl + "/{environmentName}")
+			if err != nil {
+				return fail("environments", newAPIError(resp, err))
+			}
+			envNames = append(envNames, env)
+		}
+		report("environments", envNames)
+
+		return diags
 	}
 
 	var deleteProject = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		tflog.Debug(ctx, "deleteProject")
 		tflog.Trace(ctx, fmt.Sprintf("%+v\n", data))
 
-		_, _, _, _, repos, err := unpackProject(data)
+		var diags diag.Diagnostics
+		if data.Get("force_destroy").(bool) {
+			diags = forceDestroyProject(ctx, data.Id(), m)
+			if diags.HasError() {
+				return diags
+			}
+		} else if data.Get("repos_management").(string) != reposManagementIgnore {
+			_, _, _, _, repos, err := unpackProject(data)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			deleteErr := deleteRepos(ctx, data.Id(), repos, m)
+			if deleteErr != nil {
+				return errorDiagnostics(data, withStep("unassigning repositories", fmt.Errorf("failed to delete repos for project: %w", deleteErr)))
+			}
+		}
+
+		resp, err := removeProject(ctx, data.Id(), m)
 		if err != nil {
-			return diag.FromErr(err)
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				data.SetId("")
+			}
+			// The deletion is retried while the project resources are being removed
+			return append(diags, errorDiagnostics(data, withStep("deleting project", newAPIError(resp, err)))...)
 		}
 
-		deleteErr := deleteRepos(ctx, data.Id(), repos, m)
-		if deleteErr != nil {
-			return diag.FromErr(fmt.Errorf("failed to delete repos for project: %s", deleteErr))
+		return diags
+	}
+
+	var projectRolesEnvironmentsDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if diff.Get("use_project_role_resource").(bool) || !diff.HasChange("role") {
+			return nil
 		}
 
-		req := m.(util.ProvderMetadata).Client.R()
-		req.AddRetryCondition(
-			func(r *resty.Response, _ error) bool {
-				return r.StatusCode() == http.StatusBadRequest &&
-					strings.Contains(r.String(), "project containing resources can't be removed")
-			},
-		)
+		rawConfig := diff.GetRawConfig()
+		if rawConfig.IsNull() || !rawConfig.GetAttr("role").IsWhollyKnown() || !rawConfig.GetAttr("key").IsKnown() {
+			return nil
+		}
 
-		resp, err := req.
-			SetPathParam("projectKey", data.Id()).
-			Delete(projectUrl)
+		projectKey := diff.Get("key").(string)
 
-		if err != nil {
-			if resp.StatusCode() == http.StatusNotFound {
-				data.SetId("")
+		// Environments of a new project can't be listed yet
+		var available []string
+		if diff.Id() != "" {
+			var err error
+			available, err = readEnvironmentNames(ctx, projectKey, m)
+			if err != nil {
+				return fmt.Errorf("failed to fetch environments for project: %s", err)
+			}
+		}
+
+		var errs []error
+		for _, r := range diff.Get("role").(*schema.Set).List() {
+			role := r.(map[string]interface{})
+			environments := util.CastToStringArr(role["environments"].(*schema.Set).List())
+			errs = append(errs, validateRoleEnvironments(projectKey, role["name"].(string), environments, available))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	// projectMemberRolesDiff checks the roles of `member` and `group` are predefined roles or custom roles of the
+	// project, either configured in `role` or existing on the server.
+	var projectMemberRolesDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if diff.Id() != "" && !diff.HasChanges("member", "group", "role") {
+			return nil
+		}
+
+		rawConfig := diff.GetRawConfig()
+		if rawConfig.IsNull() {
+			return nil
+		}
+		for _, key := range []string{"key", "member", "group", "role"} {
+			if !rawConfig.GetAttr(key).IsWhollyKnown() {
+				return nil
 			}
-			return diag.FromErr(err)
+		}
+
+		projectKey := diff.Get("key").(string)
+		useProjectRoleResource := diff.Get("use_project_role_resource").(bool)
+
+		known := slices.Clone(predefinedRoles)
+		if diff.Id() != "" {
+			serverRoles, err := readRoleNames(ctx, projectKey, m)
+			if err != nil {
+				return fmt.Errorf("failed to fetch roles for project: %s", err)
+			}
+			known = append(known, serverRoles...)
+		}
+
+		if !useProjectRoleResource {
+			for _, r := range diff.Get("role").(*schema.Set).List() {
+				known = append(known, r.(map[string]interface{})["name"].(string))
+			}
+		}
+
+		// Roles managed by project_role resources or copied from a template are not known at plan time
+		strict := !useProjectRoleResource && diff.Get("source_project_key").(string) == ""
+
+		var errs []error
+		for _, attribute := range []string{"member", "group"} {
+			members := membersFromSet(diff.Get(attribute).(*schema.Set))
+			errs = append(errs, validateMemberRoles(projectKey, attribute, members, known, strict))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	// projectMaxStorageDiff plans the quota in bytes from `max_storage` or `max_storage_in_gibibytes`, and the
+	// other (unconfigured) attribute in sync with it. Without either, the quota is unlimited.
+	var projectMaxStorageDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		config := diff.GetRawConfig()
+		if config.IsNull() {
+			return nil
+		}
+
+		maxStorage := config.GetAttr("max_storage")
+		maxStorageInGibibytes := config.GetAttr("max_storage_in_gibibytes")
+
+		if !maxStorage.IsKnown() || !maxStorageInGibibytes.IsKnown() {
+			for _, key := range []string{"max_storage_bytes", "max_storage", "max_storage_in_gibibytes"} {
+				if config.GetAttr(key).IsKnown() && !config.GetAttr(key).IsNull() {
+					continue
+				}
+				if err := diff.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		bytes := int64(-1)
+		if !maxStorage.IsNull() {
+			parsed, err := ParseStorage(maxStorage.AsString())
+			if err != nil {
+				return err
+			}
+			bytes = parsed
+		} else if !maxStorageInGibibytes.IsNull() {
+			gibibytes, _ := maxStorageInGibibytes.AsBigFloat().Int64()
+			bytes = GibibytesToBytes(int(gibibytes))
+		}
+
+		if diff.Id() != "" && int64(diff.Get("max_storage_bytes").(int)) == bytes {
+			return nil
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("projectMaxStorageDiff: %d bytes", bytes))
+
+		if err := diff.SetNew("max_storage_bytes", bytes); err != nil {
+			return err
+		}
+		if maxStorage.IsNull() {
+			if err := diff.SetNew("max_storage", FormatStorage(bytes)); err != nil {
+				return err
+			}
+		}
+		if maxStorageInGibibytes.IsNull() {
+			if err := diff.SetNew("max_storage_in_gibibytes", BytesToGibibytes(bytes)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// projectQuotaUsageDiff checks the lowered storage quota against the current usage of the project. A quota
+	// below or near the usage fails the plan when deployments would be blocked. A CustomizeDiff can't return
+	// warnings, so with a soft limit the check is left to updateProject, which reports it as a warning on apply.
+	var projectQuotaUsageDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if diff.Id() == "" || !diff.HasChange("max_storage_bytes") || !diff.NewValueKnown("max_storage_bytes") {
+			return nil
+		}
+
+		oldQuota, newQuota := diff.GetChange("max_storage_bytes")
+		if !quotaLowered(int64(oldQuota.(int)), int64(newQuota.(int))) {
+			return nil
+		}
+
+		if !diff.Get("block_deployments_on_limit").(bool) {
+			return nil
+		}
+
+		usage, err := readStorageUsage(ctx, diff.Id(), m)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("unable to check the storage quota against the current usage: %s", err))
+			return nil
+		}
+
+		if err := checkQuotaUsage(int64(newQuota.(int)), usage); err != nil {
+			return fmt.Errorf("%w. Deployments to the project would be blocked, increase the quota or set block_deployments_on_limit to false", err)
 		}
 
 		return nil
@@ -525,6 +1049,31 @@ func projectResource() *schema.Resource {
 		return rawState, nil
 	}
 
+	var resourceV2 = func() *schema.Resource {
+		return &schema.Resource{
+			Schema: projectSchemaV2,
+		}
+	}
+
+	var resourceStateUpgradeV2 = func(ctx context.Context, rawState map[string]any, meta any) (map[string]any, error) {
+		// set max_storage and max_storage_bytes from max_storage_in_gibibytes, they are kept in sync from now on
+		bytes := int64(-1)
+		switch v := rawState["max_storage_in_gibibytes"].(type) {
+		case float64:
+			bytes = GibibytesToBytes(int(v))
+		case json.Number:
+			gibibytes, err := v.Int64()
+			if err != nil {
+				return nil, err
+			}
+			bytes = GibibytesToBytes(int(gibibytes))
+		}
+
+		rawState["max_storage_bytes"] = bytes
+		rawState["max_storage"] = FormatStorage(bytes)
+		return rawState, nil
+	}
+
 	return &schema.Resource{
 		CreateContext: createProject,
 		ReadContext:   readProject,
@@ -535,14 +1084,34 @@ func projectResource() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema:        projectSchemaV2,
-		SchemaVersion: 2,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			projectTemplateDiff,
+			projectRolesEnvironmentsDiff,
+			projectMemberRolesDiff,
+			projectMaxStorageDiff,
+			projectQuotaUsageDiff,
+		),
+
+		Schema:        projectSchemaV3,
+		SchemaVersion: 3,
 		StateUpgraders: []schema.StateUpgrader{
 			{
 				Type:    resourceV1().CoreConfigSchema().ImpliedType(),
 				Upgrade: resourceStateUpgradeV1,
 				Version: 1,
 			},
+			{
+				Type:    resourceV2().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceStateUpgradeV2,
+				Version: 2,
+			},
 		},
 		Description: "Provides an Artifactory project resource. This can be used to create and manage Artifactory project, maintain users/groups/roles/repos.\n\n## Repository Configuration\n\nAfter the project configuration is applied, the repository's attributes `project_key` and `project_environments` would be updated with the project's data. This will generate a state drift in the next Terraform plan/apply for the repository resource. To avoid this, apply `lifecycle.ignore_changes`:\n```hcl\nresource \"artifactory_local_maven_repository\" \"my_maven_releases\" {\n\tkey = \"my-maven-releases\"\n\t...\n\n\tlifecycle {\n\t\tignore_changes = [\n\t\t\tproject_environments,\n\t\t\tproject_key\n\t\t]\n\t}\n}\n```\n~>We strongly recommend using the 'repos' attribute to manage the list of repositories. See below for additional details.",
 	}
@@ -0,0 +1,286 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+	"golang.org/x/exp/slices"
+)
+
+const projectAdminRole = "Project Admin"
+
+func projectAdminResource() *schema.Resource {
+	var projectAdminSchema = map[string]*schema.Schema{
+		"project_key": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.ProjectKey,
+			Description:      "Project key for the project administrators. This field supports only 2 - 20 lowercase alphanumeric and hyphen characters. Must begin with a letter.",
+		},
+		"users": {
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			Optional:    true,
+			Description: "Names of existing Artifactory users to be assigned the Project Admin role.",
+		},
+		"groups": {
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			Optional:    true,
+			Description: "Names of existing Artifactory groups to be assigned the Project Admin role.",
+		},
+	}
+
+	// grantProjectAdmin adds the Project Admin role to the members, keeping any other roles they already have
+	var grantProjectAdmin = func(ctx context.Context, projectKey, membershipType string, names []string, m interface{}) error {
+		tflog.Debug(ctx, "grantProjectAdmin")
+
+		projectMembers, err := readMembers(ctx, projectKey, membershipType, m)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s for project: %w", membershipType, err)
+		}
+
+		caseInsensitive := m.(ProviderMetadata).CaseInsensitiveMemberNames
+		for _, name := range names {
+			member := Member{
+				Name:  name,
+				Roles: []string{projectAdminRole},
+			}
+
+			if idx := slices.IndexFunc(projectMembers, func(projectMember Member) bool { return memberNamesEqual(projectMember.Name, name, caseInsensitive) }); idx > -1 {
+				member.Name = projectMembers[idx].Name
+				if slices.Contains(projectMembers[idx].Roles, projectAdminRole) {
+					continue
+				}
+				member.Roles = append(slices.Clone(projectMembers[idx].Roles), projectAdminRole)
+			}
+
+			err := updateMember(ctx, projectKey, membershipType, member, m)
+			if err != nil {
+				// the users and groups attributes are named after the membership type
+				return &elementError{
+					Attribute: membershipType,
+					Name:      name,
+					err:       fmt.Errorf("failed to grant %s to %s %s: %w", projectAdminRole, membershipType, name, err),
+				}
+			}
+		}
+
+		return nil
+	}
+
+	// revokeProjectAdmin removes the Project Admin role from the members, keeping any other roles they have.
+	// Members left without any role are removed from the project.
+	var revokeProjectAdmin = func(ctx context.Context, projectKey, membershipType string, names []string, m interface{}) error {
+		tflog.Debug(ctx, "revokeProjectAdmin")
+
+		projectMembers, err := readMembers(ctx, projectKey, membershipType, m)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s for project: %w", membershipType, err)
+		}
+
+		caseInsensitive := m.(ProviderMetadata).CaseInsensitiveMemberNames
+		for _, member := range projectMembers {
+			nameIdx := slices.IndexFunc(names, func(name string) bool { return memberNamesEqual(member.Name, name, caseInsensitive) })
+			if nameIdx == -1 || !slices.Contains(member.Roles, projectAdminRole) {
+				continue
+			}
+
+			roles := []string{}
+			for _, role := range member.Roles {
+				if role != projectAdminRole {
+					roles = append(roles, role)
+				}
+			}
+
+			if len(roles) == 0 {
+				err = deleteMember(ctx, projectKey, membershipType, member, m)
+			} else {
+				err = updateMember(ctx, projectKey, membershipType, Member{Name: member.Name, Roles: roles}, m)
+			}
+			if err != nil {
+				return &elementError{
+					Attribute: membershipType,
+					Name:      names[nameIdx],
+					err:       fmt.Errorf("failed to revoke %s from %s %s: %w", projectAdminRole, membershipType, member.Name, err),
+				}
+			}
+		}
+
+		return nil
+	}
+
+	// readProjectAdmins returns the members with Project Admin role. Unless all is set, only the managed members
+	// are returned so admins assigned outside of this resource do not show up as drift.
+	var readProjectAdmins = func(ctx context.Context, projectKey, membershipType string, managed []string, all bool, m interface{}) ([]string, error) {
+		projectMembers, err := readMembers(ctx, projectKey, membershipType, m)
+		if err != nil {
+			return nil, err
+		}
+
+		caseInsensitive := m.(ProviderMetadata).CaseInsensitiveMemberNames
+
+		admins := []string{}
+		for _, member := range projectMembers {
+			if !slices.Contains(member.Roles, projectAdminRole) {
+				continue
+			}
+
+			// Keep the managed name, which may differ in case from the name in Artifactory
+			idx := slices.IndexFunc(managed, func(name string) bool { return memberNamesEqual(member.Name, name, caseInsensitive) })
+			if idx > -1 {
+				admins = append(admins, managed[idx])
+			} else if all {
+				admins = append(admins, member.Name)
+			}
+		}
+
+		return admins, nil
+	}
+
+	var readProjectAdmin = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &util.ResourceData{ResourceData: data}
+		projectKey := data.Id()
+
+		users, err := readProjectAdmins(ctx, projectKey, usersMembershipType, d.GetSet("users"), false, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("reading users", err))
+		}
+
+		groups, err := readProjectAdmins(ctx, projectKey, groupssMembershipType, d.GetSet("groups"), false, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("reading groups", err))
+		}
+
+		setValue := util.MkLens(data)
+
+		setValue("project_key", projectKey)
+		setValue("users", users)
+		errors := setValue("groups", groups)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack project admin %q", errors)
+		}
+
+		return nil
+	}
+
+	var createProjectAdmin = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &util.ResourceData{ResourceData: data}
+		projectKey := d.GetString("project_key", false)
+
+		err := grantProjectAdmin(ctx, projectKey, usersMembershipType, d.GetSet("users"), m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("granting users", err))
+		}
+
+		err = grantProjectAdmin(ctx, projectKey, groupssMembershipType, d.GetSet("groups"), m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("granting groups", err))
+		}
+
+		data.SetId(projectKey)
+
+		return readProjectAdmin(ctx, data, m)
+	}
+
+	var updateProjectAdmin = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		projectKey := data.Get("project_key").(string)
+
+		for key, membershipType := range map[string]string{"users": usersMembershipType, "groups": groupssMembershipType} {
+			if !data.HasChange(key) {
+				continue
+			}
+
+			o, n := data.GetChange(key)
+			oldNames := util.CastToStringArr(o.(*schema.Set).List())
+			newNames := util.CastToStringArr(n.(*schema.Set).List())
+
+			removedNames := []string{}
+			for _, name := range oldNames {
+				if !slices.Contains(newNames, name) {
+					removedNames = append(removedNames, name)
+				}
+			}
+
+			err := revokeProjectAdmin(ctx, projectKey, membershipType, removedNames, m)
+			if err != nil {
+				return errorDiagnostics(data, withStep(fmt.Sprintf("revoking %s", key), err))
+			}
+
+			err = grantProjectAdmin(ctx, projectKey, membershipType, newNames, m)
+			if err != nil {
+				return errorDiagnostics(data, withStep(fmt.Sprintf("granting %s", key), err))
+			}
+		}
+
+		return readProjectAdmin(ctx, data, m)
+	}
+
+	var deleteProjectAdmin = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &util.ResourceData{ResourceData: data}
+		projectKey := d.GetString("project_key", false)
+
+		err := revokeProjectAdmin(ctx, projectKey, usersMembershipType, d.GetSet("users"), m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("revoking users", err))
+		}
+
+		err = revokeProjectAdmin(ctx, projectKey, groupssMembershipType, d.GetSet("groups"), m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("revoking groups", err))
+		}
+
+		data.SetId("")
+
+		return nil
+	}
+
+	// importProjectAdmin takes over all the existing project admins
+	var importProjectAdmin = func(ctx context.Context, data *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		projectKey := data.Id()
+
+		users, err := readProjectAdmins(ctx, projectKey, usersMembershipType, nil, true, m)
+		if err != nil {
+			return nil, err
+		}
+
+		groups, err := readProjectAdmins(ctx, projectKey, groupssMembershipType, nil, true, m)
+		if err != nil {
+			return nil, err
+		}
+
+		data.Set("project_key", projectKey)
+		data.Set("users", users)
+		data.Set("groups", groups)
+
+		return []*schema.ResourceData{data}, nil
+	}
+
+	return &schema.Resource{
+		CreateContext: createProjectAdmin,
+		ReadContext:   readProjectAdmin,
+		UpdateContext: updateProjectAdmin,
+		DeleteContext: deleteProjectAdmin,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importProjectAdmin,
+		},
+
+		Schema:      projectAdminSchema,
+		Description: fmt.Sprintf("Assigns the pre-defined '%s' role to users and groups of a project. The role is added to the roles the members already have instead of replacing them, and only the role is removed when a member is no longer listed. Members left without any role are removed from the project.\n\n~>Do not use in combination with the `member` and `group` attributes of the `project` resource for the same users and groups, as `project` replaces the whole membership.", projectAdminRole),
+	}
+}
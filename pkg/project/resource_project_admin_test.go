@@ -0,0 +1,181 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"golang.org/x/exp/slices"
+)
+
+func TestCreateProjectAdmin_error(t *testing.T) {
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"members":[]}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"code":"NOT_FOUND","message":"User 'missing' does not exist"}]}`))
+	}))
+
+	r := projectAdminResource()
+	data := r.TestResourceData()
+	data.Set("project_key", "test")
+	data.Set("users", []interface{}{"missing"})
+
+	diags := r.CreateContext(context.Background(), data, meta)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diags)
+	}
+	if expectedPath := cty.GetAttrPath("users"); !diags[0].AttributePath.Equals(expectedPath) {
+		t.Errorf("expected path %#v, got %#v", expectedPath, diags[0].AttributePath)
+	}
+	for _, expected := range []string{"HTTP status: 404 Not Found", "NOT_FOUND: User 'missing' does not exist", `Element: users "missing"`} {
+		if !strings.Contains(diags[0].Detail, expected) {
+			t.Errorf("expected detail to contain %q, got %q", expected, diags[0].Detail)
+		}
+	}
+}
+
+func TestAccProjectAdmin(t *testing.T) {
+	name := "tftestprojects" + randSeq(10)
+	projectKey := strings.ToLower(randSeq(6))
+	projectResourceName := "project." + name
+	resourceName := "project_admin." + name
+
+	username1 := "user1"
+	email1 := username1 + "@tempurl.org"
+	username2 := "user2"
+	email2 := username2 + "@tempurl.org"
+	group1 := "group1"
+
+	params := map[string]interface{}{
+		"name":        name,
+		"project_key": projectKey,
+		"username1":   username1,
+		"username2":   username2,
+		"group1":      group1,
+	}
+
+	// user1 is a Developer managed by the project resource, Project Admin role is added on top of it. The
+	// project ignores its members and groups so that it doesn't remove the role nor the admin group.
+	template := `
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+
+			member {
+				name = "{{ .username1 }}"
+				roles = ["Developer"]
+			}
+
+			lifecycle {
+				ignore_changes = [member, group]
+			}
+		}
+
+		resource "project_admin" "{{ .name }}" {
+			project_key = project.{{ .name }}.key
+			users       = [{{ .users }}]
+			groups      = [{{ .groups }}]
+		}
+	`
+
+	params["users"] = fmt.Sprintf(`"%s"`, username1)
+	params["groups"] = fmt.Sprintf(`"%s"`, group1)
+	initialConfig := test.ExecuteTemplate("TestAccProjectAdmin", template, params)
+
+	params["users"] = fmt.Sprintf(`"%s", "%s"`, username1, username2)
+	params["groups"] = ""
+	updatedConfig := test.ExecuteTemplate("TestAccProjectAdmin", template, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createTestUser(t, username1, email1)
+			createTestUser(t, username2, email2)
+			createTestGroup(t, group1)
+		},
+		CheckDestroy: verifyDeleted(projectResourceName, func(id string, request *resty.Request) (*resty.Response, error) {
+			deleteTestUser(t, username1)
+			deleteTestUser(t, username2)
+			deleteTestGroup(t, group1)
+			resp, err := verifyProject(id, request)
+
+			return resp, err
+		}),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: initialConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_key", projectKey),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "users.*", username1),
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "groups.*", group1),
+					testCheckMemberRoles(t, projectKey, username1, "Developer", projectAdminRole),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "users.*", username1),
+					resource.TestCheckTypeSetElemAttr(resourceName, "users.*", username2),
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "0"),
+					testCheckMemberRoles(t, projectKey, username1, "Developer", projectAdminRole),
+					testCheckMemberRoles(t, projectKey, username2, projectAdminRole),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     projectKey,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testCheckMemberRoles checks through the API that the project user has exactly the roles
+func testCheckMemberRoles(t *testing.T, projectKey, username string, roles ...string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		var member Member
+		_, err := getTestResty(t).R().
+			SetPathParams(map[string]string{
+				"projectKey": projectKey,
+				"memberName": username,
+			}).
+			SetResult(&member).
+			Get("/access/api/v1/projects/{projectKey}/users/{memberName}")
+		if err != nil {
+			return err
+		}
+
+		actual := slices.Clone(member.Roles)
+		expected := slices.Clone(roles)
+		slices.Sort(actual)
+		slices.Sort(expected)
+		if !slices.Equal(actual, expected) {
+			return fmt.Errorf("expected user %s to have roles %v in project %s, got %v", username, roles, projectKey, member.Roles)
+		}
+
+		return nil
+	}
+}
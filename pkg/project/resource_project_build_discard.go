@@ -0,0 +1,188 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const buildsUrl = "/artifactory/api/build"
+const buildRetentionUrl = buildsUrl + "/retention/{buildName}"
+
+type BuildRetention struct {
+	DeleteBuildArtifacts         bool     `json:"deleteBuildArtifacts"`
+	Count                        int      `json:"count,omitempty"`
+	MinimumBuildDate             int64    `json:"minimumBuildDate,omitempty"`
+	BuildNumbersNotToBeDiscarded []string `json:"buildNumbersNotToBeDiscarded"`
+}
+
+func buildInfoRepoKey(projectKey string) string {
+	return fmt.Sprintf("%s-build-info", projectKey)
+}
+
+func projectBuildDiscardResource() *schema.Resource {
+	var projectBuildDiscardSchema = map[string]*schema.Schema{
+		"project_key": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.ProjectKey,
+			Description:      "Project key of the builds to discard. This field supports only 2 - 20 lowercase alphanumeric and hyphen characters. Must begin with a letter.",
+		},
+		"max_builds": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			AtLeastOneOf:     []string{"max_builds", "max_days"},
+			Description:      "Number of builds to keep for each build name of the project. Older builds are discarded.",
+		},
+		"max_days": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			AtLeastOneOf:     []string{"max_builds", "max_days"},
+			Description:      "Age, in days, of the builds to keep. Builds started more than `max_days` days before the discard are discarded. The date is computed once, when the discard runs, see `discarded_before`.",
+		},
+		"delete_artifacts": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "Delete the artifacts of the discarded builds. Default to `false`.",
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Arbitrary values which run the discard again when changed, e.g. `{ date = formatdate(\"YYYY-MM\", timestamp()) }` to discard builds again every month.",
+		},
+		"discarded_before": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Date (RFC 3339) before which builds were discarded, computed from `max_days` when the discard ran. Empty without `max_days`.",
+		},
+	}
+
+	var readProjectBuilds = func(ctx context.Context, projectKey string, m interface{}) ([]string, error) {
+		type Build struct {
+			Uri string `json:"uri"`
+		}
+
+		type Builds struct {
+			Builds []Build `json:"builds"`
+		}
+
+		var builds Builds
+
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetQueryParam("project", projectKey).
+			SetResult(&builds).
+			Get(buildsUrl)
+		if err != nil {
+			err = newAPIError(resp, err)
+			// No build has been published yet
+			if errors.Is(err, ErrNotFound) {
+				return []string{}, nil
+			}
+			return nil, err
+		}
+
+		buildNames := []string{}
+		for _, build := range builds.Builds {
+			buildName, err := url.PathUnescape(strings.TrimPrefix(build.Uri, "/"))
+			if err != nil {
+				return nil, err
+			}
+			buildNames = append(buildNames, buildName)
+		}
+
+		return buildNames, nil
+	}
+
+	var discardBuilds = func(ctx context.Context, projectKey string, retention BuildRetention, m interface{}) error {
+		buildNames, err := readProjectBuilds(ctx, projectKey, m)
+		if err != nil {
+			return fmt.Errorf("failed to fetch builds for project: %w", err)
+		}
+
+		for _, buildName := range buildNames {
+			tflog.Debug(ctx, fmt.Sprintf("discardBuilds: %s", buildName))
+
+			resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+				SetPathParam("buildName", buildName).
+				SetQueryParams(map[string]string{
+					"project": projectKey,
+					"async":   "false",
+				}).
+				SetBody(retention).
+				Post(buildRetentionUrl)
+			if err != nil {
+				return fmt.Errorf("failed to discard builds of %s: %w", buildName, newAPIError(resp, err))
+			}
+		}
+
+		return nil
+	}
+
+	// readProjectBuildDiscard keeps the state as is: the discard is an action, there is no setting to read back
+	var readProjectBuildDiscard = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return nil
+	}
+
+	var createProjectBuildDiscard = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &util.ResourceData{ResourceData: data}
+		projectKey := d.GetString("project_key", false)
+
+		retention := BuildRetention{
+			DeleteBuildArtifacts:         d.GetBool("delete_artifacts", false),
+			Count:                        d.GetInt("max_builds", false),
+			BuildNumbersNotToBeDiscarded: []string{},
+		}
+
+		discardedBefore := ""
+		if maxDays := d.GetInt("max_days", false); maxDays > 0 {
+			minimumBuildDate := time.Now().AddDate(0, 0, -maxDays)
+			retention.MinimumBuildDate = minimumBuildDate.UnixMilli()
+			discardedBefore = minimumBuildDate.UTC().Format(time.RFC3339)
+		}
+
+		err := discardBuilds(ctx, projectKey, retention, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("discarding builds", err))
+		}
+
+		data.SetId(projectKey)
+		data.Set("discarded_before", discardedBefore)
+
+		return nil
+	}
+
+	var deleteProjectBuildDiscard = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		// Discarded builds can't be restored, the resource is only removed from state
+		data.SetId("")
+
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: createProjectBuildDiscard,
+		ReadContext:   readProjectBuildDiscard,
+		DeleteContext: deleteProjectBuildDiscard,
+
+		Schema:      projectBuildDiscardSchema,
+		Description: "Discards the builds of the project once, when the resource is created. This is an explicit action, not a persistent retention policy: builds published afterwards are not discarded, and `max_days` is turned into a fixed date (`discarded_before`) when the discard runs. Changing any argument, e.g. `triggers`, replaces the resource and runs the discard again.\n\nThis resource only records that the discard ran: nothing is read back from Artifactory, so there is no drift detection, and destroying it only removes it from the state. Use the `project_build_info_repository` data source to read the project's build-info repository.\n\n~>Discarded builds can't be restored.",
+	}
+}
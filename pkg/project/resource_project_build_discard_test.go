@@ -0,0 +1,163 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/test"
+)
+
+func TestCreateProjectBuildDiscard(t *testing.T) {
+	var lock sync.Mutex
+	retentions := map[string]BuildRetention{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == buildsUrl:
+			w.Write([]byte(`{"builds":[{"uri":"/build1"},{"uri":"/build%202"}]}`))
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, buildsUrl+"/retention/"):
+			if r.URL.Query().Get("project") != "test" {
+				t.Errorf("expected project query param, got %q", r.URL.RawQuery)
+			}
+			var retention BuildRetention
+			json.NewDecoder(r.Body).Decode(&retention)
+			retentions[strings.TrimPrefix(r.URL.Path, buildsUrl+"/retention/")] = retention
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	r := projectBuildDiscardResource()
+	data := r.TestResourceData()
+	data.Set("project_key", "test")
+	data.Set("max_builds", 5)
+	data.Set("max_days", 30)
+
+	before := time.Now().AddDate(0, 0, -30)
+	if diags := r.CreateContext(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(retentions) != 2 {
+		t.Fatalf("expected the discard to run for 2 builds, got %v", retentions)
+	}
+	retention := retentions["build 2"]
+	if retention.Count != 5 || retention.DeleteBuildArtifacts {
+		t.Errorf("expected count 5 without artifacts deletion, got %+v", retention)
+	}
+	if minimumBuildDate := time.UnixMilli(retention.MinimumBuildDate); minimumBuildDate.Sub(before).Abs() > time.Minute {
+		t.Errorf("expected minimum build date 30 days ago, got %s", minimumBuildDate)
+	}
+
+	if data.Id() != "test" {
+		t.Errorf("expected ID test, got %s", data.Id())
+	}
+	discardedBefore, err := time.Parse(time.RFC3339, data.Get("discarded_before").(string))
+	if err != nil || discardedBefore.Unix() != retention.MinimumBuildDate/1000 {
+		t.Errorf("expected discarded_before to be the minimum build date, got %s (%v)", data.Get("discarded_before"), err)
+	}
+}
+
+func TestCreateProjectBuildDiscard_error(t *testing.T) {
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"builds":[{"uri":"/build1"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":[{"code":"FORBIDDEN","message":"Not enough permissions"}]}`))
+	}))
+
+	r := projectBuildDiscardResource()
+	data := r.TestResourceData()
+	data.Set("project_key", "test")
+	data.Set("max_builds", 5)
+
+	diags := r.CreateContext(context.Background(), data, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Not enough permissions") {
+		t.Errorf("expected decoded API error, got %v", diags)
+	}
+	if data.Id() != "" {
+		t.Errorf("expected no ID after a failed discard, got %s", data.Id())
+	}
+}
+
+func TestAccProjectBuildDiscard(t *testing.T) {
+	skipOnFakeServer(t, "builds and build retention")
+
+	name := "tftestprojects" + randSeq(10)
+	projectKey := strings.ToLower(randSeq(6))
+	resourceName := fmt.Sprintf("project_build_discard.%s", name)
+
+	params := map[string]interface{}{
+		"name":        name,
+		"project_key": projectKey,
+		"max_builds":  10,
+	}
+
+	template := `
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+		}
+
+		resource "project_build_discard" "{{ .name }}" {
+			project_key      = project.{{ .name }}.key
+			max_builds       = {{ .max_builds }}
+			max_days         = 30
+			delete_artifacts = true
+		}
+	`
+
+	initialConfig := test.ExecuteTemplate("TestAccProjectBuildDiscard", template, params)
+
+	params["max_builds"] = 5
+	updatedConfig := test.ExecuteTemplate("TestAccProjectBuildDiscard", template, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      verifyDeleted("project."+name, verifyProject),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: initialConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_key", projectKey),
+					resource.TestCheckResourceAttr(resourceName, "max_builds", "10"),
+					resource.TestCheckResourceAttr(resourceName, "max_days", "30"),
+					resource.TestCheckResourceAttr(resourceName, "delete_artifacts", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "discarded_before"),
+				),
+			},
+			{
+				// the discard runs again with the new arguments
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "max_builds", "5"),
+					resource.TestCheckResourceAttrSet(resourceName, "discarded_before"),
+				),
+			},
+		},
+	})
+}
@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/jfrog/terraform-provider-shared/util"
 	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
@@ -55,14 +55,9 @@ func projectEnvironmentResource() *schema.Resource {
 
 	var readProjectEnvironment = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		projectKey := data.Get("project_key").(string)
-		var envs []ProjectEnvironment
-
-		_, err := m.(util.ProvderMetadata).Client.R().
-			SetPathParam("projectKey", projectKey).
-			SetResult(&envs).
-			Get(projectEnvironmentUrl)
+		envs, err := readAllPages[ProjectEnvironment](ctx, m, projectEnvironmentUrl, map[string]string{"projectKey": projectKey}, "environments")
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("reading environments", err))
 		}
 
 		var matchedEnv *ProjectEnvironment
@@ -91,12 +86,12 @@ func projectEnvironmentResource() *schema.Resource {
 			Name: fmt.Sprintf("%s-%s", projectKey, data.Get("name").(string)),
 		}
 
-		_, err := m.(util.ProvderMetadata).Client.R().
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParam("projectKey", projectKey).
 			SetBody(projectEnvironment).
 			Post(projectEnvironmentUrl)
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("creating environment", newAPIError(resp, err)))
 		}
 
 		data.SetId(projectEnvironment.Id())
@@ -112,7 +107,7 @@ func projectEnvironmentResource() *schema.Resource {
 			NewName: fmt.Sprintf("%s-%s", projectKey, newName),
 		}
 
-		_, err := m.(util.ProvderMetadata).Client.R().
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParams(map[string]string{
 				"projectKey":      projectKey,
 				"environmentName": fmt.Sprintf("%s-%s", projectKey, oldName),
@@ -120,7 +115,7 @@ func projectEnvironmentResource() *schema.Resource {
 			SetBody(projectEnvironmentUpdate).
 			Post(projectEnvironmentUrl + "/{environmentName}/rename")
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("renaming environment", newAPIError(resp, err)))
 		}
 
 		data.SetId(projectEnvironmentUpdate.Id())
@@ -131,14 +126,14 @@ func projectEnvironmentResource() *schema.Resource {
 
 	var deleteProjectEnvironment = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		projectKey := data.Get("project_key").(string)
-		_, err := m.(util.ProvderMetadata).Client.R().
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParams(map[string]string{
 				"projectKey":      projectKey,
 				"environmentName": fmt.Sprintf("%s-%s", projectKey, data.Get("name")),
 			}).
 			Delete(projectEnvironmentUrl + "/{environmentName}")
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("deleting environment", newAPIError(resp, err)))
 		}
 
 		data.SetId("")
@@ -181,6 +176,13 @@ func projectEnvironmentResource() *schema.Resource {
 			State: importForProjectKeyEnvironmentName,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		CustomizeDiff: projectEnvironmentLengthDiff,
 
 		Schema:      projectEnvironmentSchema,
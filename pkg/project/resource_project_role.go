@@ -2,10 +2,14 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -23,6 +27,16 @@ var validRoleEnvironments = []string{
 	"PROD",
 }
 
+// predefinedRoles are the roles available in every project. They can't be altered.
+var predefinedRoles = []string{
+	"Project Admin",
+	"Developer",
+	"Contributor",
+	"Viewer",
+	"Release Manager",
+	"Security Manager",
+}
+
 var validRoleActions = []string{
 	"READ_REPOSITORY",
 	"ANNOTATE_REPOSITORY",
@@ -74,6 +88,16 @@ func (a Role) Equals(b Equatable) bool {
 	return a.Id() == b.Id()
 }
 
+// Matches returns true if both roles have identical content. Unlike Equals, which only compares the name
+// to find the same role in different sets, this also compares type, description, environments and actions.
+func (a Role) Matches(b Role) bool {
+	return a.Name == b.Name &&
+		a.Type == b.Type &&
+		a.Description == b.Description &&
+		hasSameElements(a.Environments, b.Environments) &&
+		hasSameElements(a.Actions, b.Actions)
+}
+
 func projectRoleResource() *schema.Resource {
 	var projectRoleSchema = map[string]*schema.Schema{
 		"name": {
@@ -101,12 +125,15 @@ func projectRoleResource() *schema.Resource {
 			Type:        schema.TypeSet,
 			Required:    true,
 			Elem:        &schema.Schema{Type: schema.TypeString},
-			Description: fmt.Sprintf("A repository can be available in different environments. Members with roles defined in the set environment will have access to the repository. List of pre-defined environments (%s)", strings.Join(validRoleEnvironments, ", ")),
+			Description: fmt.Sprintf("A repository can be available in different environments. Members with roles defined in the set environment will have access to the repository. List of pre-defined environments (%s) or custom environments of the project. Custom project environments can be referred to by their `project_environment` name, without the project key prefix.", strings.Join(validRoleEnvironments, ", ")),
 		},
 		"actions": {
-			Type:        schema.TypeSet,
-			Required:    true,
-			Elem:        &schema.Schema{Type: schema.TypeString},
+			Type:     schema.TypeSet,
+			Required: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(stringInSliceWithSuggestion(validRoleActions)),
+			},
 			Description: fmt.Sprintf("List of pre-defined actions (%s)", strings.Join(validRoleActions, ", ")),
 		},
 	}
@@ -131,7 +158,7 @@ func projectRoleResource() *schema.Resource {
 		var role Role
 		projectKey := data.Get("project_key").(string)
 
-		_, err := m.(util.ProvderMetadata).Client.R().
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParams(map[string]string{
 				"projectKey": projectKey,
 				"roleName":   data.Id(),
@@ -140,9 +167,24 @@ func projectRoleResource() *schema.Resource {
 			Get(projectRoleUrl)
 
 		if err != nil {
-			return diag.FromErr(err)
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				tflog.Warn(ctx, fmt.Sprintf("project role %s not found, removing from state: %s", data.Id(), err))
+
+				roleName := data.Id()
+				data.SetId("")
+
+				return diag.Diagnostics{{
+					Severity: diag.Warning,
+					Summary:  "Project role not found",
+					Detail:   fmt.Sprintf("Role %s of project %s was not found, it may have been deleted outside of Terraform. It has been removed from state.", roleName, projectKey),
+				}}
+			}
+			return errorDiagnostics(data, withStep("reading role", newAPIError(resp, err)))
 		}
 
+		d := &util.ResourceData{ResourceData: data}
+		role.Environments = roleEnvironmentsFromServer(projectKey, role.Environments, d.GetSet("environments"))
+
 		return packRole(ctx, data, role, projectKey)
 	}
 
@@ -157,17 +199,69 @@ func projectRoleResource() *schema.Resource {
 		}
 	}
 
-	var createProjectRole = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// unpackServerRole returns the role with the custom environments translated to the names known by the API
+	var unpackServerRole = func(ctx context.Context, data *schema.ResourceData, m interface{}) (Role, error) {
 		projectKey := data.Get("project_key").(string)
 		role := unpackRole(data)
 
-		_, err := m.(util.ProvderMetadata).Client.R().
+		available, err := readEnvironmentNames(ctx, projectKey, m)
+		if err != nil {
+			return role, fmt.Errorf("failed to fetch environments for project: %w", err)
+		}
+		role.Environments = roleEnvironmentsToServer(projectKey, role.Environments, available)
+
+		return role, nil
+	}
+
+	// roleErrorDiagnostics points the diagnostic at the `actions` or `environments` attribute when the API error refers to it
+	var roleErrorDiagnostics = func(data *schema.ResourceData, err error) diag.Diagnostics {
+		diags := errorDiagnostics(data, err)
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if field := elementField("role", apiErr.Messages()); field != "" {
+				diags[0].AttributePath = cty.GetAttrPath(field)
+			}
+		}
+
+		return diags
+	}
+
+	var projectRoleEnvironmentsDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if diff.Id() != "" && !diff.HasChange("environments") {
+			return nil
+		}
+
+		rawConfig := diff.GetRawConfig()
+		if !rawConfig.IsWhollyKnown() {
+			return nil
+		}
+
+		projectKey := diff.Get("project_key").(string)
+		available, err := readEnvironmentNames(ctx, projectKey, m)
+		if err != nil {
+			return fmt.Errorf("failed to fetch environments for project: %s", err)
+		}
+
+		environments := util.CastToStringArr(diff.Get("environments").(*schema.Set).List())
+
+		return validateRoleEnvironments(projectKey, diff.Get("name").(string), environments, available)
+	}
+
+	var createProjectRole = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		projectKey := data.Get("project_key").(string)
+		role, err := unpackServerRole(ctx, data, m)
+		if err != nil {
+			return errorDiagnostics(data, err)
+		}
+
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParam("projectKey", projectKey).
 			SetBody(role).
 			Post(projectRolesUrl)
 
 		if err != nil {
-			return diag.FromErr(err)
+			return roleErrorDiagnostics(data, withStep("creating role", newAPIError(resp, err)))
 		}
 
 		data.SetId(role.Id())
@@ -177,9 +271,12 @@ func projectRoleResource() *schema.Resource {
 
 	var updateProjectRole = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		projectKey := data.Get("project_key").(string)
-		role := unpackRole(data)
+		role, err := unpackServerRole(ctx, data, m)
+		if err != nil {
+			return errorDiagnostics(data, err)
+		}
 
-		_, err := m.(util.ProvderMetadata).Client.R().
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParams(map[string]string{
 				"projectKey": projectKey,
 				"roleName":   role.Name,
@@ -188,7 +285,7 @@ func projectRoleResource() *schema.Resource {
 			Put(projectRoleUrl)
 
 		if err != nil {
-			return diag.FromErr(err)
+			return roleErrorDiagnostics(data, withStep("updating role", newAPIError(resp, err)))
 		}
 
 		data.SetId(role.Id())
@@ -197,7 +294,7 @@ func projectRoleResource() *schema.Resource {
 	}
 
 	var deleteProjectRole = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
-		resp, err := m.(util.ProvderMetadata).Client.R().
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 			SetPathParams(map[string]string{
 				"roleName":   data.Id(),
 				"projectKey": data.Get("project_key").(string),
@@ -205,10 +302,10 @@ func projectRoleResource() *schema.Resource {
 			Delete(projectRoleUrl)
 
 		if err != nil {
-			if resp.StatusCode() == http.StatusNotFound {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
 				data.SetId("")
 			}
-			return diag.FromErr(err)
+			return errorDiagnostics(data, withStep("deleting role", newAPIError(resp, err)))
 		}
 
 		return nil
@@ -238,6 +335,15 @@ func projectRoleResource() *schema.Resource {
 			State: importForProjectKeyRoleName,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: projectRoleEnvironmentsDiff,
+
 		Schema:      projectRoleSchema,
 		Description: "Create a project role. Element has one to one mapping with the [JFrog Project Roles API](https://www.jfrog.com/confluence/display/JFROG/Artifactory+REST+API#ArtifactoryRESTAPI-AddaNewRole). Requires a user assigned with the 'Administer the Platform' role or Project Admin permissions if `admin_privileges.manage_resoures` is enabled.",
 	}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -93,6 +94,94 @@ func TestAccProjectRole_full(t *testing.T) {
 	})
 }
 
+func TestAccProjectRole_customEnvironment(t *testing.T) {
+	name := randSeq(20)
+	resourceName := fmt.Sprintf("project_role.%s", name)
+	projectKey := strings.ToLower(randSeq(6))
+
+	template := `
+		resource "project" "{{ .project_key }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .project_key }}"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+			use_project_role_resource = true
+		}
+
+		resource "project_environment" "{{ .project_key }}" {
+			name = "staging"
+			project_key = project.{{ .project_key }}.key
+		}
+
+		resource "project_role" "{{ .name }}" {
+			name = "{{ .name }}"
+			type = "CUSTOM"
+			project_key = project.{{ .project_key }}.key
+
+			environments = ["DEV", project_environment.{{ .project_key }}.name]
+			actions = ["READ_REPOSITORY"]
+		}
+	`
+
+	config := test.ExecuteTemplate("TestAccProjectRole", template, map[string]string{
+		"name":        name,
+		"project_key": projectKey,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		CheckDestroy: verifyDeleted(resourceName, func(id string, request *resty.Request) (*resty.Response, error) {
+			return verifyRole(id, projectKey, request)
+		}),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "environments.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "environments.*", "DEV"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "environments.*", "staging"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectRole_invalidAction(t *testing.T) {
+	name := randSeq(20)
+	projectKey := strings.ToLower(randSeq(6))
+
+	template := `
+		resource "project_role" "{{ .name }}" {
+			name = "{{ .name }}"
+			type = "CUSTOM"
+			project_key = "{{ .project_key }}"
+
+			environments = ["DEV"]
+			actions = ["READ_REPO"]
+		}
+	`
+
+	config := test.ExecuteTemplate("TestAccProjectRole", template, map[string]string{
+		"name":        name,
+		"project_key": projectKey,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`.*Did you mean READ_REPOSITORY\?.*`),
+			},
+		},
+	})
+}
+
 func TestAccProjectRole_conflict_with_project(t *testing.T) {
 	name := randSeq(20)
 	resourceName := fmt.Sprintf("project_role.%s", name)
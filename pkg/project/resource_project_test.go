@@ -1,14 +1,21 @@
 package project
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-shared/test"
 )
 
@@ -16,6 +23,190 @@ func verifyProject(id string, request *resty.Request) (*resty.Response, error) {
 	return request.Head(projectsUrl + id)
 }
 
+func TestReadProject_notFound(t *testing.T) {
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"code":"NOT_FOUND","message":"Project not found"}]}`))
+	}))
+
+	for name, r := range map[string]*schema.Resource{
+		"project":      projectResource(),
+		"project_role": projectRoleResource(),
+	} {
+		data := r.TestResourceData()
+		data.SetId("test")
+		data.Set("project_key", "test")
+
+		diags := r.ReadContext(context.Background(), data, meta)
+		if diags.HasError() {
+			t.Fatalf("%s: expected no error, got %v", name, diags)
+		}
+		if len(diags) != 1 || diags[0].Severity != diag.Warning {
+			t.Errorf("%s: expected a single warning, got %v", name, diags)
+		}
+		if data.Id() != "" {
+			t.Errorf("%s: expected id to be cleared, got %s", name, data.Id())
+		}
+	}
+}
+
+func TestProject_caseInsensitiveMemberNamesPlan(t *testing.T) {
+	fake, meta := newFakeJFrogMeta(t)
+	meta.CaseInsensitiveMemberNames = true
+	ctx := context.Background()
+
+	if _, err := meta.Client.R().SetBody(map[string]string{}).Put("/artifactory/api/security/users/jane.doe"); err != nil {
+		t.Fatal(err)
+	}
+
+	adminPrivileges := []interface{}{
+		map[string]interface{}{"manage_members": true, "manage_resources": true, "index_resources": false},
+	}
+	r := projectResource()
+	data := r.TestResourceData()
+	data.Set("key", "test")
+	data.Set("display_name", "Test")
+	data.Set("admin_privileges", adminPrivileges)
+	data.Set("member", []interface{}{
+		map[string]interface{}{"name": "jane.doe", "roles": []interface{}{"Developer"}},
+	})
+	if diags := r.CreateContext(ctx, data, meta); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	// the name only differs in case from the state
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key":              "test",
+		"display_name":     "Test",
+		"admin_privileges": adminPrivileges,
+		"member": []interface{}{
+			map[string]interface{}{"name": "JANE.DOE", "roles": []interface{}{"Developer"}},
+		},
+	})
+	diff, err := r.Diff(ctx, data.State(), config, meta)
+	if err != nil {
+		t.Fatalf("expected no error on plan, got %v", err)
+	}
+
+	fake.lock.Lock()
+	fake.requests = nil
+	fake.lock.Unlock()
+
+	state, diags := r.Apply(ctx, data.State(), diff, meta)
+	if diags.HasError() {
+		t.Fatalf("expected no error on apply, got %v", diags)
+	}
+	members := membersFromSet(r.Data(state).Get("member").(*schema.Set))
+	if len(members) != 1 || members[0].Name != "JANE.DOE" {
+		t.Errorf("expected the configured name in state, got %v", members)
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	for _, request := range fake.requests {
+		if strings.Contains(request, "/users/") {
+			t.Errorf("expected the member to be left untouched, got %s", request)
+		}
+	}
+}
+
+func TestDeleteProject_forceDestroy(t *testing.T) {
+	var lock sync.Mutex
+	deletes := []string{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodDelete {
+			lock.Lock()
+			deletes = append(deletes, r.URL.Path)
+			lock.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/artifactory/api/repositories":
+			w.Write([]byte(`[{"key":"test-repo"},{"key":"oob-repo"}]`))
+		case "/access/api/v1/projects/test/users":
+			w.Write([]byte(`{"members":[{"name":"user1","roles":["qa"]}]}`))
+		case "/access/api/v1/projects/test/groups":
+			w.Write([]byte(`{"members":[]}`))
+		case "/access/api/v1/projects/test/roles":
+			w.Write([]byte(`[{"name":"Developer","type":"PREDEFINED"},{"name":"qa","type":"CUSTOM"}]`))
+		case "/access/api/v1/projects/test/environments":
+			w.Write([]byte(`[{"name":"DEV"},{"name":"PROD"},{"name":"test-qa"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	// a single worker unassigns the repos in order
+	meta.RepositoryWorkers = 1
+
+	r := projectResource()
+	data := r.TestResourceData()
+	data.SetId("test")
+	data.Set("key", "test")
+	data.Set("force_destroy", true)
+	data.Set("repos", []interface{}{"test-repo"})
+
+	diags := r.DeleteContext(context.Background(), data, meta)
+	if diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+	if len(diags) != 5 {
+		t.Errorf("expected a warning for each step, got %v", diags)
+	}
+
+	expectedDeletes := []string{
+		"/access/api/v1/projects/_/attach/repositories/test-repo",
+		"/access/api/v1/projects/_/attach/repositories/oob-repo",
+		"/access/api/v1/projects/test/users/user1",
+		"/access/api/v1/projects/test/roles/qa",
+		"/access/api/v1/projects/test/environments/test-qa",
+		"/access/api/v1/projects/test",
+	}
+	if strings.Join(deletes, ",") != strings.Join(expectedDeletes, ",") {
+		t.Errorf("expected DELETE %v, got %v", expectedDeletes, deletes)
+	}
+}
+
+func TestProjectStateUpgradeV2(t *testing.T) {
+	upgrader := projectResource().StateUpgraders[1]
+	if upgrader.Version != 2 {
+		t.Fatalf("expected upgrader from version 2, got %d", upgrader.Version)
+	}
+
+	testCases := []struct {
+		gibibytes  any
+		bytes      int64
+		maxStorage string
+	}{
+		{float64(10), 10_737_418_240, "10GiB"},
+		{float64(1024), 1_099_511_627_776, "1TiB"},
+		{float64(-1), -1, "unlimited"},
+		{json.Number("465"), 499_289_948_160, "465GiB"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.maxStorage, func(t *testing.T) {
+			rawState := map[string]any{"max_storage_in_gibibytes": tc.gibibytes}
+
+			upgraded, err := upgrader.Upgrade(context.Background(), rawState, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if upgraded["max_storage_bytes"] != tc.bytes {
+				t.Errorf("expected max_storage_bytes %d, got %v", tc.bytes, upgraded["max_storage_bytes"])
+			}
+			if upgraded["max_storage"] != tc.maxStorage {
+				t.Errorf("expected max_storage %s, got %v", tc.maxStorage, upgraded["max_storage"])
+			}
+		})
+	}
+}
+
 func getRandomMaxStorageSize() int {
 	randomMaxStorage := rand.Intn(maxStorageInGibibytes)
 	if randomMaxStorage == 0 {
@@ -215,6 +406,76 @@ func TestAccProjectInvalidDisplayName(t *testing.T) {
 	})
 }
 
+func TestAccProject_maxStorage(t *testing.T) {
+	name := fmt.Sprintf("tftestprojects%s", randSeq(10))
+	resourceName := fmt.Sprintf("project.%s", name)
+	projectKey := strings.ToLower(randSeq(6))
+
+	template := `
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			description = "test description"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+			max_storage = "{{ .max_storage }}"
+		}
+	`
+	config := func(maxStorage string) string {
+		return test.ExecuteTemplate("TestAccProjectMaxStorage", template, map[string]interface{}{
+			"name":        name,
+			"project_key": projectKey,
+			"max_storage": maxStorage,
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      verifyDeleted(resourceName, verifyProject),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("500GB"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "max_storage", "500GB"),
+					resource.TestCheckResourceAttr(resourceName, "max_storage_bytes", "500000000000"),
+					resource.TestCheckResourceAttr(resourceName, "max_storage_in_gibibytes", "465"),
+				),
+			},
+			{
+				// Same quota with another unit
+				Config:   config("0.5TB"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("1.5TiB"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "max_storage", "1.5TiB"),
+					resource.TestCheckResourceAttr(resourceName, "max_storage_bytes", "1649267441664"),
+					resource.TestCheckResourceAttr(resourceName, "max_storage_in_gibibytes", "1536"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_project_role_resource", "force_destroy", "rollback_on_failure", "repos_management", "membership_management", "max_storage"},
+			},
+			{
+				Config: config("unlimited"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "max_storage", "unlimited"),
+					resource.TestCheckResourceAttr(resourceName, "max_storage_bytes", "-1"),
+					resource.TestCheckResourceAttr(resourceName, "max_storage_in_gibibytes", "-1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccProjectUpdateKey(t *testing.T) {
 	name := fmt.Sprintf("testprojects%s", randSeq(20))
 	resourceName := fmt.Sprintf("project.%s", name)
@@ -457,7 +718,7 @@ func TestAccProject_full(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"use_project_role_resource"},
+				ImportStateVerifyIgnore: []string{"use_project_role_resource", "force_destroy", "rollback_on_failure", "repos_management", "membership_management"},
 			},
 		},
 	})
@@ -0,0 +1,299 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+	"golang.org/x/exp/slices"
+)
+
+const xrayBinMgrId = "default"
+const xrayIndexedReposUrl = "/xray/api/v1/binMgr/{binMgrId}/repos"
+const xrayIndexedBuildsUrl = "/xray/api/v1/binMgr/{binMgrId}/builds"
+
+type XrayIndexedRepo struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	PackageType string `json:"pkg_type"`
+}
+
+type XrayIndexedRepos struct {
+	IndexedRepos    []XrayIndexedRepo `json:"indexed_repos"`
+	NonIndexedRepos []XrayIndexedRepo `json:"non_indexed_repos"`
+}
+
+type XrayIndexedBuilds struct {
+	IndexedBuilds    []string `json:"indexed_builds"`
+	NonIndexedBuilds []string `json:"non_indexed_builds"`
+}
+
+func projectXrayIndexedResourcesResource() *schema.Resource {
+	var projectXrayIndexedResourcesSchema = map[string]*schema.Schema{
+		"project_key": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.ProjectKey,
+			Description:      "Project key for the indexed resources. This field supports only 2 - 20 lowercase alphanumeric and hyphen characters. Must begin with a letter.",
+		},
+		"repos": {
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			Optional:    true,
+			Description: "Keys of the project repositories to be indexed by Xray. Repositories must be assigned to the project. Project repositories not in this list are not indexed.",
+		},
+		"builds": {
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			Optional:    true,
+			Description: "Names of the project builds to be indexed by Xray. Project builds not in this list are not indexed.",
+		},
+	}
+
+	// readProjectRepoDetails returns the project repositories, including the type information required by Xray
+	var readProjectRepoDetails = func(ctx context.Context, projectKey string, m interface{}) (map[string]XrayIndexedRepo, error) {
+		type ArtifactoryRepo struct {
+			Key         string `json:"key"`
+			Type        string `json:"type"`
+			PackageType string `json:"packageType"`
+		}
+
+		artifactoryRepos, err := readAllPages[ArtifactoryRepo](ctx, m, "/artifactory/api/repositories?project={projectKey}", map[string]string{
+			"projectKey": projectKey,
+		}, "repositories")
+		if err != nil {
+			return nil, err
+		}
+
+		repos := map[string]XrayIndexedRepo{}
+		for _, repo := range artifactoryRepos {
+			// Artifactory returns upper case repo types (e.g. LOCAL), Xray expects lower case
+			repos[repo.Key] = XrayIndexedRepo{
+				Name:        repo.Key,
+				Type:        strings.ToLower(repo.Type),
+				PackageType: repo.PackageType,
+			}
+		}
+
+		return repos, nil
+	}
+
+	var readXrayIndexedRepos = func(ctx context.Context, m interface{}) (XrayIndexedRepos, error) {
+		var indexedRepos XrayIndexedRepos
+
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetPathParam("binMgrId", xrayBinMgrId).
+			SetResult(&indexedRepos).
+			Get(xrayIndexedReposUrl)
+
+		return indexedRepos, newAPIError(resp, err)
+	}
+
+	var readXrayIndexedBuilds = func(ctx context.Context, projectKey string, m interface{}) (XrayIndexedBuilds, error) {
+		var indexedBuilds XrayIndexedBuilds
+
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetPathParam("binMgrId", xrayBinMgrId).
+			SetQueryParam("projectKey", projectKey).
+			SetResult(&indexedBuilds).
+			Get(xrayIndexedBuildsUrl)
+
+		return indexedBuilds, newAPIError(resp, err)
+	}
+
+	// updateXrayIndexedRepos sets the indexed project repositories to repoKeys. The binMgr configuration is
+	// global and replaced by the PUT, so the indexed and non indexed repositories outside the project are
+	// written back as read.
+	var updateXrayIndexedRepos = func(ctx context.Context, projectKey string, repoKeys []string, m interface{}) error {
+		tflog.Debug(ctx, "updateXrayIndexedRepos")
+
+		projectRepos, err := readProjectRepoDetails(ctx, projectKey, m)
+		if err != nil {
+			return fmt.Errorf("failed to fetch repos for project: %w", err)
+		}
+
+		for _, repoKey := range repoKeys {
+			if _, ok := projectRepos[repoKey]; !ok {
+				return &elementError{
+					Attribute: "repos",
+					Name:      repoKey,
+					err:       fmt.Errorf("repo %s is not assigned to project %s", repoKey, projectKey),
+				}
+			}
+		}
+
+		indexedRepos, err := readXrayIndexedRepos(ctx, m)
+		if err != nil {
+			return fmt.Errorf("failed to fetch Xray indexed repos: %w", err)
+		}
+
+		updatedIndexedRepos := XrayIndexedRepos{
+			IndexedRepos:    []XrayIndexedRepo{},
+			NonIndexedRepos: []XrayIndexedRepo{},
+		}
+
+		for _, repo := range indexedRepos.IndexedRepos {
+			if _, ok := projectRepos[repo.Name]; !ok {
+				updatedIndexedRepos.IndexedRepos = append(updatedIndexedRepos.IndexedRepos, repo)
+			}
+		}
+
+		for _, repo := range indexedRepos.NonIndexedRepos {
+			if _, ok := projectRepos[repo.Name]; !ok {
+				updatedIndexedRepos.NonIndexedRepos = append(updatedIndexedRepos.NonIndexedRepos, repo)
+			}
+		}
+
+		for key, repo := range projectRepos {
+			if slices.Contains(repoKeys, key) {
+				updatedIndexedRepos.IndexedRepos = append(updatedIndexedRepos.IndexedRepos, repo)
+			} else {
+				updatedIndexedRepos.NonIndexedRepos = append(updatedIndexedRepos.NonIndexedRepos, repo)
+			}
+		}
+
+		tflog.Trace(ctx, fmt.Sprintf("updatedIndexedRepos: %+v\n", updatedIndexedRepos))
+
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetPathParam("binMgrId", xrayBinMgrId).
+			SetBody(updatedIndexedRepos).
+			Put(xrayIndexedReposUrl)
+
+		return newAPIError(resp, err)
+	}
+
+	// updateXrayIndexedBuilds sets the indexed project builds to buildNames. The PUT replaces the whole project
+	// configuration, so the other builds known to Xray are written back as non indexed.
+	var updateXrayIndexedBuilds = func(ctx context.Context, projectKey string, buildNames []string, m interface{}) error {
+		tflog.Debug(ctx, "updateXrayIndexedBuilds")
+
+		indexedBuilds, err := readXrayIndexedBuilds(ctx, projectKey, m)
+		if err != nil {
+			return fmt.Errorf("failed to fetch Xray indexed builds: %w", err)
+		}
+
+		updatedIndexedBuilds := XrayIndexedBuilds{
+			IndexedBuilds:    []string{},
+			NonIndexedBuilds: []string{},
+		}
+		updatedIndexedBuilds.IndexedBuilds = append(updatedIndexedBuilds.IndexedBuilds, buildNames...)
+
+		for _, build := range append(indexedBuilds.IndexedBuilds, indexedBuilds.NonIndexedBuilds...) {
+			if !slices.Contains(buildNames, build) && !slices.Contains(updatedIndexedBuilds.NonIndexedBuilds, build) {
+				updatedIndexedBuilds.NonIndexedBuilds = append(updatedIndexedBuilds.NonIndexedBuilds, build)
+			}
+		}
+
+		tflog.Trace(ctx, fmt.Sprintf("updatedIndexedBuilds: %+v\n", updatedIndexedBuilds))
+
+		resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetPathParam("binMgrId", xrayBinMgrId).
+			SetQueryParam("projectKey", projectKey).
+			SetBody(updatedIndexedBuilds).
+			Put(xrayIndexedBuildsUrl)
+
+		return newAPIError(resp, err)
+	}
+
+	var readProjectXrayIndexedResources = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		projectKey := data.Id()
+
+		projectRepos, err := readProjectRepoDetails(ctx, projectKey, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("reading repositories", err))
+		}
+
+		indexedRepos, err := readXrayIndexedRepos(ctx, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("reading indexed repositories", err))
+		}
+
+		repoKeys := []string{}
+		for _, repo := range indexedRepos.IndexedRepos {
+			if _, ok := projectRepos[repo.Name]; ok {
+				repoKeys = append(repoKeys, repo.Name)
+			}
+		}
+
+		indexedBuilds, err := readXrayIndexedBuilds(ctx, projectKey, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("reading indexed builds", err))
+		}
+
+		setValue := util.MkLens(data)
+
+		setValue("project_key", projectKey)
+		setValue("repos", repoKeys)
+		errors := setValue("builds", indexedBuilds.IndexedBuilds)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack project Xray indexed resources %q", errors)
+		}
+
+		return nil
+	}
+
+	var updateProjectXrayIndexedResources = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &util.ResourceData{ResourceData: data}
+		projectKey := d.GetString("project_key", false)
+
+		err := updateXrayIndexedRepos(ctx, projectKey, d.GetSet("repos"), m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("updating indexed repositories", err))
+		}
+
+		err = updateXrayIndexedBuilds(ctx, projectKey, d.GetSet("builds"), m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("updating indexed builds", err))
+		}
+
+		data.SetId(projectKey)
+
+		return readProjectXrayIndexedResources(ctx, data, m)
+	}
+
+	var deleteProjectXrayIndexedResources = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		projectKey := data.Get("project_key").(string)
+
+		err := updateXrayIndexedRepos(ctx, projectKey, []string{}, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("removing indexed repositories", err))
+		}
+
+		err = updateXrayIndexedBuilds(ctx, projectKey, []string{}, m)
+		if err != nil {
+			return errorDiagnostics(data, withStep("removing indexed builds", err))
+		}
+
+		data.SetId("")
+
+		return nil
+	}
+
+	return &schema.Resource{
+		CreateContext: updateProjectXrayIndexedResources,
+		ReadContext:   readProjectXrayIndexedResources,
+		UpdateContext: updateProjectXrayIndexedResources,
+		DeleteContext: deleteProjectXrayIndexedResources,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema:      projectXrayIndexedResourcesSchema,
+		Description: "Manages the project repositories and builds to be indexed by Xray. This resource is authoritative: project repositories and builds not listed are removed from Xray indexing. Requires `admin_privileges.index_resources` to be enabled on the project when used with a Project Admin token.",
+	}
+}
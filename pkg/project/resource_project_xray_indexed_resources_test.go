@@ -0,0 +1,230 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"golang.org/x/exp/slices"
+)
+
+func TestUpdateProjectXrayIndexedResources_merge(t *testing.T) {
+	var lock sync.Mutex
+	indexedRepos := XrayIndexedRepos{
+		IndexedRepos: []XrayIndexedRepo{
+			{Name: "other-indexed", Type: "local", PackageType: "generic"},
+			{Name: "test-repo2", Type: "local", PackageType: "generic"},
+		},
+		NonIndexedRepos: []XrayIndexedRepo{
+			{Name: "other-non-indexed", Type: "remote", PackageType: "npm"},
+			{Name: "test-repo1", Type: "local", PackageType: "generic"},
+		},
+	}
+	indexedBuilds := XrayIndexedBuilds{
+		IndexedBuilds:    []string{"test-build1"},
+		NonIndexedBuilds: []string{"test-build2"},
+	}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /artifactory/api/repositories":
+			if r.URL.Query().Get("project") != "test" {
+				t.Errorf("expected project query param, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"key":"test-repo1","type":"LOCAL","packageType":"generic"},{"key":"test-repo2","type":"LOCAL","packageType":"generic"}]`))
+		case "GET /xray/api/v1/binMgr/default/repos":
+			json.NewEncoder(w).Encode(indexedRepos)
+		case "PUT /xray/api/v1/binMgr/default/repos":
+			indexedRepos = XrayIndexedRepos{}
+			json.NewDecoder(r.Body).Decode(&indexedRepos)
+		case "GET /xray/api/v1/binMgr/default/builds":
+			json.NewEncoder(w).Encode(indexedBuilds)
+		case "PUT /xray/api/v1/binMgr/default/builds":
+			if r.URL.Query().Get("projectKey") != "test" {
+				t.Errorf("expected projectKey query param, got %q", r.URL.RawQuery)
+			}
+			indexedBuilds = XrayIndexedBuilds{}
+			json.NewDecoder(r.Body).Decode(&indexedBuilds)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	names := func(repos []XrayIndexedRepo) []string {
+		names := []string{}
+		for _, repo := range repos {
+			names = append(names, repo.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+	sorted := func(values []string) []string {
+		values = slices.Clone(values)
+		slices.Sort(values)
+		return values
+	}
+
+	r := projectXrayIndexedResourcesResource()
+	data := r.TestResourceData()
+	data.Set("project_key", "test")
+	data.Set("repos", []interface{}{"test-repo1"})
+	data.Set("builds", []interface{}{"test-build2"})
+
+	if diags := r.CreateContext(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	lock.Lock()
+	if actual := names(indexedRepos.IndexedRepos); !slices.Equal(actual, []string{"other-indexed", "test-repo1"}) {
+		t.Errorf("expected indexed repos outside the project to be kept, got %v", actual)
+	}
+	if actual := names(indexedRepos.NonIndexedRepos); !slices.Equal(actual, []string{"other-non-indexed", "test-repo2"}) {
+		t.Errorf("expected non indexed repos outside the project to be kept, got %v", actual)
+	}
+	if actual := sorted(indexedBuilds.IndexedBuilds); !slices.Equal(actual, []string{"test-build2"}) {
+		t.Errorf("expected indexed builds [test-build2], got %v", actual)
+	}
+	if actual := sorted(indexedBuilds.NonIndexedBuilds); !slices.Equal(actual, []string{"test-build1"}) {
+		t.Errorf("expected non indexed builds [test-build1], got %v", actual)
+	}
+	lock.Unlock()
+
+	if repos := data.Get("repos").(*schema.Set); repos.Len() != 1 || !repos.Contains("test-repo1") {
+		t.Errorf("expected repos [test-repo1] in state, got %v", repos.List())
+	}
+	if builds := data.Get("builds").(*schema.Set); builds.Len() != 1 || !builds.Contains("test-build2") {
+		t.Errorf("expected builds [test-build2] in state, got %v", builds.List())
+	}
+}
+
+// publishTestBuild publishes a minimal build info to the project, to be indexed by Xray
+func publishTestBuild(t *testing.T, projectKey, name string) {
+	build := map[string]string{
+		"version": "1.0.1",
+		"name":    name,
+		"number":  "1",
+		"started": "2023-01-01T00:00:00.000+0000",
+	}
+
+	_, err := getTestResty(t).R().
+		SetQueryParam("project", projectKey).
+		SetBody(build).
+		Put("/artifactory/api/build")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAccProjectXrayIndexedResources(t *testing.T) {
+	skipOnFakeServer(t, "Xray")
+
+	name := "tftestprojects" + randSeq(10)
+	projectKey := strings.ToLower(randSeq(6))
+	resourceName := fmt.Sprintf("project_xray_indexed_resources.%s", name)
+
+	repo1 := fmt.Sprintf("repo%d", test.RandomInt())
+	repo2 := fmt.Sprintf("repo%d", test.RandomInt())
+	build := fmt.Sprintf("build%d", test.RandomInt())
+
+	params := map[string]interface{}{
+		"name":        name,
+		"project_key": projectKey,
+		"repo1":       repo1,
+		"repo2":       repo2,
+		"indexed":     fmt.Sprintf(`"%s"`, repo1),
+		"builds":      "",
+	}
+
+	template := `
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			admin_privileges {
+				manage_members = true
+				manage_resources = true
+				index_resources = true
+			}
+
+			repos = ["{{ .repo1 }}", "{{ .repo2 }}"]
+		}
+
+		resource "project_xray_indexed_resources" "{{ .name }}" {
+			project_key = project.{{ .name }}.key
+			repos       = [{{ .indexed }}]
+			builds      = [{{ .builds }}]
+		}
+	`
+
+	initialConfig := test.ExecuteTemplate("TestAccProjectXrayIndexedResources", template, params)
+
+	params["indexed"] = fmt.Sprintf(`"%s", "%s"`, repo1, repo2)
+	updatedConfig := test.ExecuteTemplate("TestAccProjectXrayIndexedResources", template, params)
+
+	params["builds"] = fmt.Sprintf(`"%s"`, build)
+	buildsConfig := test.ExecuteTemplate("TestAccProjectXrayIndexedResources", template, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createTestRepo(t, repo1)
+			createTestRepo(t, repo2)
+		},
+		CheckDestroy: verifyDeleted("project."+name, func(id string, request *resty.Request) (*resty.Response, error) {
+			deleteTestRepo(t, repo1)
+			deleteTestRepo(t, repo2)
+			resp, err := verifyProject(id, request)
+
+			return resp, err
+		}),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: initialConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_key", projectKey),
+					resource.TestCheckResourceAttr(resourceName, "repos.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "repos.*", repo1),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_key", projectKey),
+					resource.TestCheckResourceAttr(resourceName, "repos.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "repos.*", repo1),
+					resource.TestCheckTypeSetElemAttr(resourceName, "repos.*", repo2),
+					resource.TestCheckResourceAttr(resourceName, "builds.#", "0"),
+				),
+			},
+			{
+				// the build is published to the project created by the previous steps
+				PreConfig: func() { publishTestBuild(t, projectKey, build) },
+				Config:    buildsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "repos.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "builds.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "builds.*", build),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportStateId:     projectKey,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
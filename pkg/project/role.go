@@ -2,11 +2,14 @@ package project
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jfrog/terraform-provider-shared/util"
+	"golang.org/x/exp/slices"
 )
 
 var unpackRoles = func(data *schema.ResourceData) []Role {
@@ -78,13 +81,7 @@ func filterRoles(roles []Role, roleType string) []Role {
 var readRoles = func(ctx context.Context, projectKey string, m interface{}) ([]Role, error) {
 	tflog.Debug(ctx, "readRoles")
 
-	roles := []Role{}
-
-	_, err := m.(util.ProvderMetadata).Client.R().
-		SetPathParam("projectKey", projectKey).
-		SetResult(&roles).
-		Get(projectRolesUrl)
-
+	roles, err := readAllPages[Role](ctx, m, projectRolesUrl, map[string]string{"projectKey": projectKey}, "roles")
 	if err != nil {
 		return nil, err
 	}
@@ -99,45 +96,97 @@ var readRoles = func(ctx context.Context, projectKey string, m interface{}) ([]R
 	return customRoles, nil
 }
 
-var updateRoles = func(ctx context.Context, projectKey string, terraformRoles []Role, m interface{}) ([]Role, error) {
+// readRoleNames returns the names of all the project roles, predefined and custom. It returns nil if the
+// project does not exist (yet).
+var readRoleNames = func(ctx context.Context, projectKey string, m interface{}) ([]string, error) {
+	tflog.Debug(ctx, "readRoleNames")
+
+	roles, err := readAllPages[Role](ctx, m, projectRolesUrl, map[string]string{"projectKey": projectKey}, "roles")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	names := []string{}
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+
+	return names, nil
+}
+
+var updateRoles = func(ctx context.Context, projectKey string, terraformRoles []Role, unmanaged []string, m interface{}) ([]Role, error) {
 	tflog.Debug(ctx, "updateRoles")
 	tflog.Trace(ctx, fmt.Sprintf("terraformRoles: %+v\n", terraformRoles))
 
 	projectRoles, err := readRoles(ctx, projectKey, m)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch roles for project: %s", err)
+		return nil, fmt.Errorf("failed to fetch roles for project: %w", err)
 	}
 	tflog.Trace(ctx, fmt.Sprintf("projectRoles: %+v\n", projectRoles))
 
+	available, err := readEnvironmentNames(ctx, projectKey, m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch environments for project: %w", err)
+	}
+
+	// Roles are compared with and sent as the API returns them, i.e. with prefixed custom environments
+	serverRoles := make([]Role, 0, len(terraformRoles))
+	for _, role := range terraformRoles {
+		role.Environments = roleEnvironmentsToServer(projectKey, role.Environments, available)
+		serverRoles = append(serverRoles, role)
+	}
+	terraformRoles = serverRoles
+
 	terraformRolesSet := SetFromSlice(terraformRoles)
 	projectRolesSet := SetFromSlice(projectRoles)
 
 	rolesToBeAdded := terraformRolesSet.Difference(projectRolesSet)
 	tflog.Trace(ctx, fmt.Sprintf("rolesToBeAdded: %+v\n", rolesToBeAdded))
 
-	rolesToBeUpdated := terraformRolesSet.Intersection(projectRolesSet)
+	// Only roles with different content need to be updated
+	rolesToBeUpdated := []Role{}
+	for _, role := range terraformRolesSet.Intersection(projectRolesSet).Items() {
+		idx := slices.IndexFunc(projectRoles, func(r Role) bool { return r.Equals(role) })
+		if !role.Matches(projectRoles[idx]) {
+			rolesToBeUpdated = append(rolesToBeUpdated, role)
+		}
+	}
 	tflog.Trace(ctx, fmt.Sprintf("rolesToBeUpdated: %+v\n", rolesToBeUpdated))
 
-	rolesToBeDeleted := projectRolesSet.Difference(terraformRolesSet)
+	// Unmanaged roles, e.g. copied from a template, are never deleted
+	rolesToBeDeleted := projectRolesSet.Difference(terraformRolesSet).filter(func(role Role) bool {
+		return !slices.Contains(unmanaged, role.Name)
+	})
 	tflog.Trace(ctx, fmt.Sprintf("rolesToBeDeleted: %+v\n", rolesToBeDeleted))
 
-	for _, role := range rolesToBeAdded {
+	for _, role := range rolesToBeAdded.Items() {
 		err := addRole(ctx, projectKey, role, m)
 		if err != nil {
-			return nil, fmt.Errorf("failed to add role %s: %s", role, err)
+			return nil, &elementError{
+				Attribute: "role",
+				Name:      role.Name,
+				err:       fmt.Errorf("failed to add role %s: %w", role, err),
+			}
 		}
 	}
 
 	for _, role := range rolesToBeUpdated {
 		err := updateRole(ctx, projectKey, role, m)
 		if err != nil {
-			return nil, fmt.Errorf("failed to update role %s: %s", role, err)
+			return nil, &elementError{
+				Attribute: "role",
+				Name:      role.Name,
+				err:       fmt.Errorf("failed to update role %s: %w", role, err),
+			}
 		}
 	}
 
-	deleteErr := deleteRoles(ctx, projectKey, rolesToBeDeleted, m)
+	deleteErr := deleteRoles(ctx, projectKey, rolesToBeDeleted.Items(), m)
 	if deleteErr != nil {
-		return nil, fmt.Errorf("failed to delete roles for project: %s", deleteErr)
+		return nil, fmt.Errorf("failed to delete roles for project: %w", deleteErr)
 	}
 
 	return readRoles(ctx, projectKey, m)
@@ -146,18 +195,18 @@ var updateRoles = func(ctx context.Context, projectKey string, terraformRoles []
 var addRole = func(ctx context.Context, projectKey string, role Role, m interface{}) error {
 	tflog.Debug(ctx, "addRole")
 
-	_, err := m.(util.ProvderMetadata).Client.R().
+	resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 		SetPathParam("projectKey", projectKey).
 		SetBody(role).
 		Post(projectRolesUrl)
 
-	return err
+	return newAPIError(resp, err)
 }
 
 var updateRole = func(ctx context.Context, projectKey string, role Role, m interface{}) error {
 	tflog.Debug(ctx, "updateRole")
 
-	_, err := m.(util.ProvderMetadata).Client.R().
+	resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 		SetPathParams(map[string]string{
 			"projectKey": projectKey,
 			"roleName":   role.Name,
@@ -165,7 +214,7 @@ var updateRole = func(ctx context.Context, projectKey string, role Role, m inter
 		SetBody(role).
 		Put(projectRoleUrl)
 
-	return err
+	return newAPIError(resp, err)
 }
 
 var deleteRoles = func(ctx context.Context, projectKey string, roles []Role, m interface{}) error {
@@ -174,7 +223,7 @@ var deleteRoles = func(ctx context.Context, projectKey string, roles []Role, m i
 	for _, role := range roles {
 		err := deleteRole(ctx, projectKey, role, m)
 		if err != nil {
-			return fmt.Errorf("failed to delete role %s: %s", role, err)
+			return fmt.Errorf("failed to delete role %s: %w", role, err)
 		}
 	}
 
@@ -185,7 +234,7 @@ var deleteRole = func(ctx context.Context, projectKey string, role Role, m inter
 	tflog.Debug(ctx, "deleteRole")
 	tflog.Trace(ctx, fmt.Sprintf("%+v\n", role))
 
-	_, err := m.(util.ProvderMetadata).Client.R().
+	resp, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
 		SetPathParams(map[string]string{
 			"projectKey": projectKey,
 			"roleName":   role.Name,
@@ -194,8 +243,137 @@ var deleteRole = func(ctx context.Context, projectKey string, role Role, m inter
 		Delete(projectRoleUrl)
 
 	if err != nil {
-		return err
+		return newAPIError(resp, err)
 	}
 
 	return nil
 }
+
+// readEnvironmentNames returns the names of the environments available to the project roles, i.e. the global
+// and the project environments. It returns nil if the project does not exist (yet).
+var readEnvironmentNames = func(ctx context.Context, projectKey string, m interface{}) ([]string, error) {
+	tflog.Debug(ctx, "readEnvironmentNames")
+
+	envs, err := readAllPages[ProjectEnvironment](ctx, m, projectEnvironmentUrl, map[string]string{"projectKey": projectKey}, "environments")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	names := []string{}
+	for _, env := range envs {
+		names = append(names, env.Name)
+	}
+
+	return names, nil
+}
+
+// roleEnvironmentsToServer translates the environments of a role to the names known by the API. Custom project
+// environments can be referred to by their short name, e.g. `staging` for `<project_key>-staging`, as in
+// `project_environment`. Pre-defined, global and already prefixed environments are sent as is.
+func roleEnvironmentsToServer(projectKey string, environments, available []string) []string {
+	prefix := projectKey + "-"
+
+	translated := make([]string, 0, len(environments))
+	for _, env := range environments {
+		if !slices.Contains(validRoleEnvironments, env) && !slices.Contains(available, env) && !strings.HasPrefix(env, prefix) {
+			env = prefix + env
+		}
+		translated = append(translated, env)
+	}
+
+	return translated
+}
+
+// roleEnvironmentsFromServer translates the environments returned by the API back to the names used in the
+// configuration. Custom project environments are shortened unless configured with the project key prefix.
+func roleEnvironmentsFromServer(projectKey string, environments, configured []string) []string {
+	prefix := projectKey + "-"
+
+	translated := make([]string, 0, len(environments))
+	for _, env := range environments {
+		if strings.HasPrefix(env, prefix) && !slices.Contains(configured, env) {
+			env = strings.TrimPrefix(env, prefix)
+		}
+		translated = append(translated, env)
+	}
+
+	return translated
+}
+
+// rolesFromServer translates the environments of the roles returned by the API, using the configured roles
+// with the same name
+func rolesFromServer(projectKey string, roles, configured []Role) []Role {
+	translated := make([]Role, 0, len(roles))
+	for _, role := range roles {
+		var configuredEnvs []string
+		if idx := slices.IndexFunc(configured, func(r Role) bool { return r.Name == role.Name }); idx > -1 {
+			configuredEnvs = configured[idx].Environments
+		}
+
+		role.Environments = roleEnvironmentsFromServer(projectKey, role.Environments, configuredEnvs)
+		translated = append(translated, role)
+	}
+
+	return translated
+}
+
+// validateRoleEnvironments checks the environments of a role against the pre-defined environments and the
+// available environments of the project, by full or short name. When the available environments are known,
+// unknown environments are errors, with a suggestion when one is close enough. Otherwise (e.g. for a new
+// project), only the unknown environments close to a pre-defined one are reported as typos. Environments
+// created in the same apply should be referenced through their `project_environment` resource, so that they
+// are unknown until created and not validated.
+func validateRoleEnvironments(projectKey, roleName string, environments, available []string) error {
+	candidates := slices.Clone(validRoleEnvironments)
+	for _, env := range available {
+		if !slices.Contains(candidates, env) {
+			candidates = append(candidates, env)
+		}
+		if strings.HasPrefix(env, projectKey+"-") {
+			candidates = append(candidates, strings.TrimPrefix(env, projectKey+"-"))
+		}
+	}
+
+	var errs []error
+	for _, env := range environments {
+		if slices.Contains(candidates, env) {
+			continue
+		}
+
+		if suggestion := closestMatch(env, candidates); suggestion != "" {
+			errs = append(errs, fmt.Errorf("role %s: environment %s is not available in project %s. Did you mean %s?", roleName, env, projectKey, suggestion))
+		} else if available != nil {
+			errs = append(errs, fmt.Errorf("role %s: environment %s is not available in project %s, expected one of %v", roleName, env, projectKey, candidates))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateMemberRoles checks the roles of the members against the known roles of the project, with an exact match.
+// Unknown roles close to a known one, including with a different case, are reported as typos. Other unknown roles are only reported when strict, i.e. when all the roles
+// of the project are known at plan time.
+func validateMemberRoles(projectKey, attribute string, members []Member, known []string, strict bool) error {
+	var errs []error
+	for _, member := range members {
+		for _, role := range member.Roles {
+			if slices.Contains(known, role) {
+				continue
+			}
+
+			if suggestion := closestMatch(role, known); suggestion != "" {
+				errs = append(errs, fmt.Errorf("%s %s: role %s is not defined in project %s. Did you mean %s?", attribute, member.Name, role, projectKey, suggestion))
+				continue
+			}
+
+			if strict {
+				errs = append(errs, fmt.Errorf("%s %s: role %s is not defined in project %s, expected one of %v", attribute, member.Name, role, projectKey, known))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
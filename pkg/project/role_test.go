@@ -1,14 +1,117 @@
 package project
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/jfrog/terraform-provider-shared/test"
+	"golang.org/x/exp/slices"
 )
 
+func TestUpdateRoles_onlyChangedRoles(t *testing.T) {
+	projectRoles := []Role{
+		{
+			Name:         "Developer",
+			Type:         "PREDEFINED",
+			Environments: []string{"DEV"},
+			Actions:      []string{"READ_REPOSITORY"},
+		},
+	}
+	for i := 0; i < 40; i++ {
+		projectRoles = append(projectRoles, Role{
+			Name:         fmt.Sprintf("role %d", i),
+			Description:  "test description",
+			Type:         customRoleType,
+			Environments: []string{"DEV", "PROD"},
+			Actions:      []string{"READ_REPOSITORY", "ANNOTATE_REPOSITORY"},
+		})
+	}
+
+	var lock sync.Mutex
+	calls := map[string]int{}
+
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		calls[r.Method]++
+		lock.Unlock()
+
+		if r.Method == http.MethodGet && r.URL.Path == "/access/api/v1/projects/test/roles" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(projectRoles)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Same content in a different order, except for one role with an additional action
+	terraformRoles := []Role{}
+	for i := 0; i < 40; i++ {
+		role := Role{
+			Name:         fmt.Sprintf("role %d", i),
+			Description:  "test description",
+			Type:         customRoleType,
+			Environments: []string{"PROD", "DEV"},
+			Actions:      []string{"ANNOTATE_REPOSITORY", "READ_REPOSITORY"},
+		}
+		if i == 7 {
+			role.Actions = append(role.Actions, "DEPLOY_CACHE_REPOSITORY")
+		}
+		terraformRoles = append(terraformRoles, role)
+	}
+
+	_, err := updateRoles(context.Background(), "test", terraformRoles, nil, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls[http.MethodPut] != 1 {
+		t.Errorf("expected 1 PUT, got %d", calls[http.MethodPut])
+	}
+	if calls[http.MethodPost] != 0 {
+		t.Errorf("expected 0 POST, got %d", calls[http.MethodPost])
+	}
+	if calls[http.MethodDelete] != 0 {
+		t.Errorf("expected 0 DELETE, got %d", calls[http.MethodDelete])
+	}
+}
+
+func TestRole_Matches(t *testing.T) {
+	role := Role{
+		Name:         "role",
+		Description:  "test description",
+		Type:         customRoleType,
+		Environments: []string{"DEV", "PROD"},
+		Actions:      []string{"READ_REPOSITORY"},
+	}
+
+	testCases := []struct {
+		name     string
+		other    Role
+		expected bool
+	}{
+		{"same", role, true},
+		{"different order", Role{Name: "role", Description: "test description", Type: customRoleType, Environments: []string{"PROD", "DEV"}, Actions: []string{"READ_REPOSITORY"}}, true},
+		{"different description", Role{Name: "role", Description: "other", Type: customRoleType, Environments: []string{"DEV", "PROD"}, Actions: []string{"READ_REPOSITORY"}}, false},
+		{"different environments", Role{Name: "role", Description: "test description", Type: customRoleType, Environments: []string{"DEV"}, Actions: []string{"READ_REPOSITORY"}}, false},
+		{"different actions", Role{Name: "role", Description: "test description", Type: customRoleType, Environments: []string{"DEV", "PROD"}, Actions: []string{"READ_REPOSITORY", "ANNOTATE_REPOSITORY"}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := role.Matches(tc.other); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
 func TestAccProject_role(t *testing.T) {
 	name := "tftestprojects" + randSeq(10)
 	resourceName := "project." + name
@@ -162,7 +265,7 @@ func TestAccProject_role(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"use_project_role_resource"},
+				ImportStateVerifyIgnore: []string{"use_project_role_resource", "force_destroy", "rollback_on_failure", "repos_management", "membership_management"},
 			},
 			{
 				Config: noUserConfig,
@@ -176,3 +279,97 @@ func TestAccProject_role(t *testing.T) {
 		},
 	})
 }
+
+func TestValidateRoleEnvironments(t *testing.T) {
+	available := []string{"DEV", "PROD", "STAGING", "test-qa"}
+
+	testCases := []struct {
+		name         string
+		environments []string
+		available    []string
+		expectedErr  string
+	}{
+		{name: "valid", environments: []string{"DEV", "STAGING", "test-qa"}, available: available},
+		{name: "typo", environments: []string{"PRD"}, available: available, expectedErr: "Did you mean PROD?"},
+		{name: "project environment typo", environments: []string{"test-qaa"}, available: available, expectedErr: "Did you mean test-qa?"},
+		{name: "short name", environments: []string{"qa"}, available: available},
+		{name: "short name typo", environments: []string{"qaa"}, available: available, expectedErr: "Did you mean qa?"},
+		{name: "unknown short name", environments: []string{"UAT"}, available: available, expectedErr: "role role1: environment UAT is not available in project test, expected one of"},
+		{name: "unknown project environment", environments: []string{"test-integration"}, available: available, expectedErr: "environment test-integration is not available in project test, expected one of"},
+		{name: "unknown without suggestion", environments: []string{"SOMETHING_ELSE"}, available: available, expectedErr: "expected one of [DEV PROD STAGING test-qa qa]"},
+		{name: "new project", environments: []string{"UAT"}, available: nil},
+		{name: "new project typo", environments: []string{"dev"}, available: nil, expectedErr: "Did you mean DEV?"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRoleEnvironments("test", "role1", tc.environments, tc.available)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected error containing %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateMemberRoles(t *testing.T) {
+	known := append(slices.Clone(predefinedRoles), "qa")
+
+	testCases := []struct {
+		name        string
+		members     []Member
+		strict      bool
+		expectedErr string
+	}{
+		{name: "valid", members: []Member{{Name: "user1", Roles: []string{"Developer", "qa"}}}, strict: true},
+		{name: "case", members: []Member{{Name: "user1", Roles: []string{"developer"}}}, strict: false, expectedErr: "member user1: role developer is not defined in project test. Did you mean Developer?"},
+		{name: "typo", members: []Member{{Name: "user1", Roles: []string{"Developr"}}}, strict: false, expectedErr: "member user1: role Developr is not defined in project test. Did you mean Developer?"},
+		{name: "unknown strict", members: []Member{{Name: "user1", Roles: []string{"devops"}}}, strict: true, expectedErr: "member user1: role devops is not defined in project test"},
+		{name: "unknown not strict", members: []Member{{Name: "user1", Roles: []string{"devops"}}}, strict: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateMemberRoles("test", "member", tc.members, known, tc.strict)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected error containing %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRoleEnvironmentsTranslation(t *testing.T) {
+	available := []string{"DEV", "PROD", "STAGING", "test-qa"}
+
+	toServer := roleEnvironmentsToServer("test", []string{"DEV", "STAGING", "qa", "test-qa", "uat"}, available)
+	expected := []string{"DEV", "STAGING", "test-qa", "test-qa", "test-uat"}
+	if strings.Join(toServer, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, toServer)
+	}
+
+	fromServer := roleEnvironmentsFromServer("test", []string{"DEV", "STAGING", "test-qa", "test-uat"}, []string{"DEV", "STAGING", "qa", "test-uat"})
+	expected = []string{"DEV", "STAGING", "qa", "test-uat"}
+	if strings.Join(fromServer, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, fromServer)
+	}
+
+	// Imported roles have no configuration, custom environments are shortened
+	fromServer = roleEnvironmentsFromServer("test", []string{"PROD", "test-qa"}, nil)
+	expected = []string{"PROD", "qa"}
+	if strings.Join(fromServer, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, fromServer)
+	}
+}
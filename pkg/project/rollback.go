@@ -0,0 +1,106 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// createStep is one step of the project creation. Undo is optional and is called to roll back the completed
+// step. It is only called for the failed step itself when UndoFailed is set, for steps which may fail half
+// way (e.g. some repos were assigned before an error).
+type createStep struct {
+	Name       string
+	Do         func() error
+	Undo       func() error
+	UndoFailed bool
+}
+
+// createStepsResult records what happened to the steps so it can be reported in diagnostics
+type createStepsResult struct {
+	Completed   []string
+	Failed      string
+	Err         error
+	RolledBack  []string
+	RollbackErr error
+}
+
+// runCreateSteps runs the steps in order and stops at the first failure. When rollback is set, the completed
+// steps are undone in reverse order, after the failed step if it may have failed half way.
+func runCreateSteps(ctx context.Context, steps []createStep, rollback bool) createStepsResult {
+	result := createStepsResult{}
+
+	failedIdx := -1
+	for idx, step := range steps {
+		tflog.Debug(ctx, fmt.Sprintf("runCreateSteps: %s", step.Name))
+
+		if err := step.Do(); err != nil {
+			result.Failed = step.Name
+			result.Err = withStep(fmt.Sprintf("creating %s", step.Name), err)
+			failedIdx = idx
+			break
+		}
+		result.Completed = append(result.Completed, step.Name)
+	}
+
+	if failedIdx == -1 || !rollback {
+		return result
+	}
+
+	var rollbackErrs []error
+	for idx := failedIdx; idx >= 0; idx-- {
+		step := steps[idx]
+		if step.Undo == nil || (idx == failedIdx && !step.UndoFailed) {
+			continue
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("runCreateSteps: rolling back %s", step.Name))
+
+		if err := step.Undo(); err != nil {
+			rollbackErrs = append(rollbackErrs, fmt.Errorf("failed to roll back %s: %s", step.Name, err))
+			continue
+		}
+		result.RolledBack = append(result.RolledBack, step.Name)
+	}
+	result.RollbackErr = errors.Join(rollbackErrs...)
+
+	return result
+}
+
+// Diagnostics returns an error diagnostic listing the completed and rolled back steps
+func (r createStepsResult) Diagnostics(data *schema.ResourceData) diag.Diagnostics {
+	if r.Err == nil {
+		return nil
+	}
+
+	completed := "none"
+	if len(r.Completed) > 0 {
+		completed = strings.Join(r.Completed, ", ")
+	}
+
+	detail := fmt.Sprintf("Completed steps: %s.", completed)
+	if len(r.RolledBack) > 0 {
+		detail += fmt.Sprintf("\nRolled back steps: %s.", strings.Join(r.RolledBack, ", "))
+	}
+
+	diags := errorDiagnostics(data, fmt.Errorf("failed to create project at step %s: %w", r.Failed, r.Err))
+	if diags[0].Detail != "" {
+		detail = diags[0].Detail + "\n" + detail
+	}
+	diags[0].Detail = detail
+
+	if r.RollbackErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "failed to roll back project creation",
+			Detail:   r.RollbackErr.Error(),
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,221 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestRunCreateSteps(t *testing.T) {
+	var calls []string
+
+	makeSteps := func(failAt string) []createStep {
+		steps := []createStep{}
+		for _, name := range []string{"project", "roles", "members", "repos"} {
+			name := name
+			step := createStep{
+				Name: name,
+				Do: func() error {
+					calls = append(calls, "do "+name)
+					if name == failAt {
+						return fmt.Errorf("%s failed", name)
+					}
+					return nil
+				},
+			}
+			if name != "roles" && name != "members" {
+				step.Undo = func() error {
+					calls = append(calls, "undo "+name)
+					return nil
+				}
+				step.UndoFailed = name == "repos"
+			}
+			steps = append(steps, step)
+		}
+		return steps
+	}
+
+	testCases := []struct {
+		name          string
+		failAt        string
+		rollback      bool
+		expectedCalls []string
+		completed     []string
+		rolledBack    []string
+	}{
+		{
+			name:          "success",
+			failAt:        "",
+			rollback:      true,
+			expectedCalls: []string{"do project", "do roles", "do members", "do repos"},
+			completed:     []string{"project", "roles", "members", "repos"},
+		},
+		{
+			name:          "failure without rollback",
+			failAt:        "members",
+			rollback:      false,
+			expectedCalls: []string{"do project", "do roles", "do members"},
+			completed:     []string{"project", "roles"},
+		},
+		{
+			name:          "failure with rollback",
+			failAt:        "members",
+			rollback:      true,
+			expectedCalls: []string{"do project", "do roles", "do members", "undo project"},
+			completed:     []string{"project", "roles"},
+			rolledBack:    []string{"project"},
+		},
+		{
+			name:          "failed step is rolled back",
+			failAt:        "repos",
+			rollback:      true,
+			expectedCalls: []string{"do project", "do roles", "do members", "do repos", "undo repos", "undo project"},
+			completed:     []string{"project", "roles", "members"},
+			rolledBack:    []string{"repos", "project"},
+		},
+		{
+			name:          "failed step is not rolled back",
+			failAt:        "project",
+			rollback:      true,
+			expectedCalls: []string{"do project"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+
+			result := runCreateSteps(context.Background(), makeSteps(tc.failAt), tc.rollback)
+
+			if strings.Join(calls, ",") != strings.Join(tc.expectedCalls, ",") {
+				t.Errorf("expected calls %v, got %v", tc.expectedCalls, calls)
+			}
+			if strings.Join(result.Completed, ",") != strings.Join(tc.completed, ",") {
+				t.Errorf("expected completed %v, got %v", tc.completed, result.Completed)
+			}
+			if strings.Join(result.RolledBack, ",") != strings.Join(tc.rolledBack, ",") {
+				t.Errorf("expected rolled back %v, got %v", tc.rolledBack, result.RolledBack)
+			}
+			if (tc.failAt == "") != (result.Err == nil) {
+				t.Errorf("unexpected error: %v", result.Err)
+			}
+		})
+	}
+}
+
+func TestCreateStepsResult_Diagnostics(t *testing.T) {
+	result := createStepsResult{
+		Completed:   []string{"project", "roles"},
+		Failed:      "members",
+		Err:         fmt.Errorf("user not found"),
+		RollbackErr: fmt.Errorf("failed to roll back project: 500"),
+	}
+
+	diags := result.Diagnostics(nil)
+	if len(diags) != 2 || diags[0].Severity != diag.Error {
+		t.Fatalf("expected 2 error diagnostics, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Summary, "step members: user not found") {
+		t.Errorf("unexpected summary: %s", diags[0].Summary)
+	}
+	if diags[0].Detail != "Completed steps: project, roles." {
+		t.Errorf("unexpected detail: %s", diags[0].Detail)
+	}
+}
+
+func TestCreateProject_rollbackRepos(t *testing.T) {
+	fake := newFakeJFrog()
+	// other-repo can't be moved from its project, e.g. for lack of permissions
+	meta := newTestMeta(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/access/api/v1/projects/_/attach/repositories/other-repo/test" {
+			accessError(w, http.StatusForbidden, "FORBIDDEN", "Not enough permissions")
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	meta.Client.SetAuthToken(fakeAccessToken)
+	ctx := context.Background()
+
+	if _, err := meta.Client.R().SetBody(Project{Key: "other", DisplayName: "Other"}).Post(projectsUrl); err != nil {
+		t.Fatal(err)
+	}
+	for _, repoKey := range []string{"test-repo", "other-repo"} {
+		if _, err := meta.Client.R().SetBody(map[string]string{"key": repoKey, "rclass": "local"}).Put("/artifactory/api/repositories/" + repoKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := addRepos(ctx, "other", []RepoKey{"other-repo"}, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	r := projectResource()
+	data := r.TestResourceData()
+	data.Set("key", "test")
+	data.Set("display_name", "Test")
+	data.Set("admin_privileges", []interface{}{
+		map[string]interface{}{"manage_members": true, "manage_resources": true, "index_resources": false},
+	})
+	data.Set("repos", []interface{}{"test-repo", "other-repo"})
+	data.Set("rollback_on_failure", true)
+
+	diags := r.CreateContext(ctx, data, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "Rolled back steps: repos, project.") {
+		t.Fatalf("expected repos and project to be rolled back, got %v", diags)
+	}
+	if data.Id() != "" {
+		t.Errorf("expected no ID after rollback, got %q", data.Id())
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	if _, ok := fake.projects["test"]; ok {
+		t.Error("expected project to be deleted")
+	}
+	if projectKey := fake.repos["test-repo"].ProjectKey; projectKey != "" {
+		t.Errorf("expected test-repo to be unassigned, got %q", projectKey)
+	}
+	if projectKey := fake.repos["other-repo"].ProjectKey; projectKey != "other" {
+		t.Errorf("expected other-repo to stay in project other, got %q", projectKey)
+	}
+}
+
+func TestCreateProject_rollbackExistingProject(t *testing.T) {
+	fake, meta := newFakeJFrogMeta(t)
+	ctx := context.Background()
+
+	if _, err := meta.Client.R().SetBody(Project{Key: "test", DisplayName: "Existing"}).Post(projectsUrl); err != nil {
+		t.Fatal(err)
+	}
+
+	r := projectResource()
+	data := r.TestResourceData()
+	data.Set("key", "test")
+	data.Set("display_name", "Test")
+	data.Set("admin_privileges", []interface{}{
+		map[string]interface{}{"manage_members": true, "manage_resources": true, "index_resources": false},
+	})
+	data.Set("rollback_on_failure", true)
+
+	diags := r.CreateContext(ctx, data, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "409") {
+		t.Fatalf("expected a conflict error, got %v", diags)
+	}
+	if strings.Contains(diags[0].Detail, "Rolled back steps") {
+		t.Errorf("expected nothing to be rolled back, got %s", diags[0].Detail)
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	for _, request := range fake.requests {
+		if strings.HasPrefix(request, http.MethodDelete) {
+			t.Errorf("expected no DELETE request, got %s", request)
+		}
+	}
+	if project, ok := fake.projects["test"]; !ok || project.DisplayName != "Existing" {
+		t.Error("expected the existing project to be kept")
+	}
+}
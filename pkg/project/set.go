@@ -1,43 +1,95 @@
 package project
 
-type Set[T Equatable] []T
+import "fmt"
+
+// Set is a set of items identified by their Id(), e.g. the members or repos of a project. It keeps the
+// order in which the items were added, so logs and API calls are stable. Items with the same Id() as an
+// item already in the set are ignored.
+type Set[T Equatable] struct {
+	items []T
+	index map[string]struct{}
+}
 
 func SetFromSlice[T Equatable](values []T) Set[T] {
-	set := make(Set[T], 0)
-	set = append(set, values...)
+	set := Set[T]{
+		items: make([]T, 0, len(values)),
+		index: make(map[string]struct{}, len(values)),
+	}
+	for _, value := range values {
+		set.Add(value)
+	}
 	return set
 }
 
+// Add adds the item to the set, and returns false if an item with the same Id() is already in the set
+func (s *Set[T]) Add(item T) bool {
+	if s.Contains(item) {
+		return false
+	}
+
+	if s.index == nil {
+		s.index = map[string]struct{}{}
+	}
+	s.index[item.Id()] = struct{}{}
+	s.items = append(s.items, item)
+	return true
+}
+
 func (s Set[T]) Contains(b T) bool {
-	for _, a := range s {
-		if a.Equals(b) {
-			return true
+	_, ok := s.index[b.Id()]
+	return ok
+}
+
+func (s Set[T]) Len() int {
+	return len(s.items)
+}
+
+// Items returns the items of the set, in the order they were added
+func (s Set[T]) Items() []T {
+	return s.items
+}
+
+func (s Set[T]) String() string {
+	return fmt.Sprintf("%+v", s.items)
+}
+
+// filter returns a Set containing the items for which keep returns true
+func (s Set[T]) filter(keep func(T) bool) Set[T] {
+	filtered := SetFromSlice[T](nil)
+	for _, item := range s.items {
+		if keep(item) {
+			filtered.Add(item)
 		}
 	}
-	return false
+	return filtered
 }
 
 // Intersection returns a Set containing all the common items between both Sets.
 // Example: [1, 2, 3].Intersection([2, 3, 4]) = [2, 3].
 func (s Set[T]) Intersection(other Set[T]) Set[T] {
-	intersection := make(Set[T], 0)
-	for _, item := range s {
-		if other.Contains(item) {
-			intersection = append(intersection, item)
-		}
-	}
-	return intersection
+	return s.filter(other.Contains)
 }
 
 // Difference returns a Set containing all the items not contained in the other set.
 // Note this is "unidirectional", and the result is _only_ the elements in A that are not in B.
 // Example: [1, 2, 3].Difference([2, 3, 4]) = [1].
 func (s Set[T]) Difference(other Set[T]) Set[T] {
-	diff := make(Set[T], 0)
-	for _, item := range s {
-		if !other.Contains(item) {
-			diff = append(diff, item)
-		}
+	return s.filter(func(item T) bool { return !other.Contains(item) })
+}
+
+// Union returns a Set containing the items of both Sets, the items of this Set first.
+// Example: [1, 2, 3].Union([2, 3, 4]) = [1, 2, 3, 4].
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := SetFromSlice(s.items)
+	for _, item := range other.items {
+		union.Add(item)
 	}
-	return diff
+	return union
+}
+
+// SymmetricDifference returns a Set containing the items contained in only one of the Sets, the items of
+// this Set first.
+// Example: [1, 2, 3].SymmetricDifference([2, 3, 4]) = [1, 4].
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	return s.Difference(other).Union(other.Difference(s))
 }
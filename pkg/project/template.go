@@ -0,0 +1,202 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"golang.org/x/exp/slices"
+)
+
+// ProjectTemplate holds the configuration copied from a source project when creating a project from a template
+type ProjectTemplate struct {
+	SourceProjectKey string
+	AdminPrivileges  AdminPrivileges
+	Environments     []string
+	Roles            []Role
+	Users            []Member
+	Groups           []Member
+}
+
+var readProjectTemplate = func(ctx context.Context, sourceProjectKey string, m interface{}) (ProjectTemplate, error) {
+	tflog.Debug(ctx, "readProjectTemplate")
+
+	template := ProjectTemplate{
+		SourceProjectKey: sourceProjectKey,
+	}
+
+	sourceProject := Project{}
+	_, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+		SetPathParam("projectKey", sourceProjectKey).
+		SetResult(&sourceProject).
+		Get(projectUrl)
+	if err != nil {
+		return template, fmt.Errorf("failed to fetch source project %s: %s", sourceProjectKey, err)
+	}
+	template.AdminPrivileges = sourceProject.AdminPrivileges
+
+	var envs []ProjectEnvironment
+	_, err = m.(ProviderMetadata).Client.R().SetContext(ctx).
+		SetPathParam("projectKey", sourceProjectKey).
+		SetResult(&envs).
+		Get(projectEnvironmentUrl)
+	if err != nil {
+		return template, fmt.Errorf("failed to fetch environments for source project %s: %s", sourceProjectKey, err)
+	}
+
+	// Only the project's own environments are copied, global environments (e.g. DEV, PROD) are shared already
+	envPrefix := fmt.Sprintf("%s-", sourceProjectKey)
+	for _, env := range envs {
+		if strings.HasPrefix(env.Name, envPrefix) {
+			template.Environments = append(template.Environments, strings.TrimPrefix(env.Name, envPrefix))
+		}
+	}
+
+	template.Roles, err = readRoles(ctx, sourceProjectKey, m)
+	if err != nil {
+		return template, fmt.Errorf("failed to fetch roles for source project %s: %s", sourceProjectKey, err)
+	}
+
+	template.Users, err = readMembers(ctx, sourceProjectKey, usersMembershipType, m)
+	if err != nil {
+		return template, fmt.Errorf("failed to fetch users for source project %s: %s", sourceProjectKey, err)
+	}
+
+	template.Groups, err = readMembers(ctx, sourceProjectKey, groupssMembershipType, m)
+	if err != nil {
+		return template, fmt.Errorf("failed to fetch groups for source project %s: %s", sourceProjectKey, err)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("template: %+v\n", template))
+
+	return template, nil
+}
+
+// createTemplateEnvironments creates the environments copied from the template for the project
+var createTemplateEnvironments = func(ctx context.Context, projectKey string, template ProjectTemplate, m interface{}) error {
+	tflog.Debug(ctx, "createTemplateEnvironments")
+
+	for _, env := range template.Environments {
+		_, err := m.(ProviderMetadata).Client.R().SetContext(ctx).
+			SetPathParam("projectKey", projectKey).
+			SetBody(ProjectEnvironment{
+				Name: fmt.Sprintf("%s-%s", projectKey, env),
+			}).
+			Post(projectEnvironmentUrl)
+		if err != nil {
+			return fmt.Errorf("failed to create environment %s: %s", env, err)
+		}
+	}
+
+	return nil
+}
+
+// templateRoles returns the roles from the template translated for the project, followed by the
+// configured roles. Configured roles take precedence over template roles with the same name.
+func templateRoles(projectKey string, template ProjectTemplate, roles []Role) []Role {
+	configuredRoles := SetFromSlice(roles)
+	merged := []Role{}
+
+	for _, role := range template.Roles {
+		if configuredRoles.Contains(role) {
+			continue
+		}
+
+		// Custom environments of roles must point to the copied environments of the project
+		environments := make([]string, 0, len(role.Environments))
+		for _, env := range role.Environments {
+			if strings.HasPrefix(env, template.SourceProjectKey+"-") {
+				env = projectKey + strings.TrimPrefix(env, template.SourceProjectKey)
+			}
+			environments = append(environments, env)
+		}
+		role.Environments = environments
+
+		merged = append(merged, role)
+	}
+
+	return append(merged, roles...)
+}
+
+// templateMembers returns the members from the template followed by the configured members.
+// Configured members take precedence over template members with the same name.
+func templateMembers(templateMembers []Member, membership Membership) Membership {
+	configuredMembers := SetFromSlice(membership.Members)
+	merged := []Member{}
+
+	for _, member := range templateMembers {
+		if !configuredMembers.Contains(member) {
+			merged = append(merged, member)
+		}
+	}
+
+	return Membership{
+		Members: append(merged, membership.Members...),
+	}
+}
+
+// copiedNames returns the names of the items copied from the template which are not configured
+func copiedNames[T Equatable](copied, configured []T) []string {
+	configuredSet := SetFromSlice(configured)
+	names := []string{}
+
+	for _, item := range copied {
+		if !configuredSet.Contains(item) {
+			names = append(names, item.Id())
+		}
+	}
+
+	return names
+}
+
+// withoutCopied returns the items without the ones copied from the template, unless they are configured
+func withoutCopied[T Equatable](items []T, copied []string, configured []T) []T {
+	configuredSet := SetFromSlice(configured)
+	kept := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if configuredSet.Contains(item) || !slices.Contains(copied, item.Id()) {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept
+}
+
+// templateNames returns the names of a `template_*` attribute
+func templateNames(data *schema.ResourceData, key string) []string {
+	d := &util.ResourceData{ResourceData: data}
+	return d.GetSet(key)
+}
+
+// isConfigured returns true if the attribute is set in configuration, or if it is not known yet
+func isConfigured(rawConfig cty.Value, key string) bool {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return true
+	}
+
+	value := rawConfig.GetAttr(key)
+	if !value.IsKnown() {
+		return true
+	}
+
+	return !value.IsNull() && value.LengthInt() > 0
+}
+
+// projectTemplateDiff requires `admin_privileges` for projects not created from a template. Once created, the
+// project keeps its admin privileges when they are not configured.
+var projectTemplateDiff = func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Id() != "" || !diff.NewValueKnown("source_project_key") || diff.Get("source_project_key").(string) != "" {
+		return nil
+	}
+
+	if !isConfigured(diff.GetRawConfig(), "admin_privileges") {
+		return fmt.Errorf("admin_privileges is required unless source_project_key is set")
+	}
+
+	return nil
+}
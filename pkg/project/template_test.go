@@ -0,0 +1,226 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/test"
+)
+
+func TestAccProject_sourceProjectKey(t *testing.T) {
+	templateName := "tftestprojects" + randSeq(10)
+	templateKey := strings.ToLower(randSeq(6))
+	name := "tftestprojects" + randSeq(10)
+	resourceName := "project." + name
+	projectKey := strings.ToLower(randSeq(6))
+
+	username1 := "user1"
+	email1 := username1 + "@tempurl.org"
+	username2 := "user2"
+	email2 := username2 + "@tempurl.org"
+
+	params := map[string]interface{}{
+		"template_name": templateName,
+		"template_key":  templateKey,
+		"name":          name,
+		"project_key":   projectKey,
+		"username1":     username1,
+		"username2":     username2,
+	}
+
+	templateConfig := `
+		resource "project" "{{ .template_name }}" {
+			key = "{{ .template_key }}"
+			display_name = "{{ .template_name }}"
+			admin_privileges {
+				manage_members = true
+				manage_resources = false
+				index_resources = true
+			}
+
+			member {
+				name = "{{ .username1 }}"
+				roles = ["Developer"]
+			}
+
+			role {
+				name = "template role"
+				type = "CUSTOM"
+				environments = ["DEV"]
+				actions = ["READ_REPOSITORY"]
+			}
+		}
+	`
+
+	// Admin privileges, members and roles are copied, and not reported in the configured attributes
+	initialConfig := test.ExecuteTemplate("TestAccProjectSourceProjectKey", templateConfig+`
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			source_project_key = project.{{ .template_name }}.key
+		}
+	`, params)
+
+	// source_project_key is ignored after creation, copied members are left untouched
+	updatedConfig := test.ExecuteTemplate("TestAccProjectSourceProjectKey", templateConfig+`
+		resource "project" "{{ .name }}" {
+			key = "{{ .project_key }}"
+			display_name = "{{ .name }}"
+			source_project_key = "unused"
+
+			member {
+				name = "{{ .username2 }}"
+				roles = ["Contributor"]
+			}
+		}
+	`, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			createTestUser(t, username1, email1)
+			createTestUser(t, username2, email2)
+		},
+		CheckDestroy: verifyDeleted(resourceName, func(id string, request *resty.Request) (*resty.Response, error) {
+			deleteTestUser(t, username1)
+			deleteTestUser(t, username2)
+			resp, err := verifyProject(id, request)
+
+			return resp, err
+		}),
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: initialConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "key", projectKey),
+					resource.TestCheckResourceAttr(resourceName, "source_project_key", templateKey),
+					resource.TestCheckResourceAttr(resourceName, "admin_privileges.0.manage_members", "true"),
+					resource.TestCheckResourceAttr(resourceName, "admin_privileges.0.manage_resources", "false"),
+					resource.TestCheckResourceAttr(resourceName, "admin_privileges.0.index_resources", "true"),
+					resource.TestCheckResourceAttr(resourceName, "member.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "template_members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "template_members.*", username1),
+					resource.TestCheckResourceAttr(resourceName, "role.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "template_roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "template_roles.*", "template role"),
+				),
+			},
+			{
+				// the copied values don't show up as drift
+				Config:   initialConfig,
+				PlanOnly: true,
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "key", projectKey),
+					resource.TestCheckResourceAttr(resourceName, "source_project_key", templateKey),
+					resource.TestCheckResourceAttr(resourceName, "member.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "member.0.name", username2),
+					resource.TestCheckResourceAttr(resourceName, "template_members.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "role.#", "0"),
+					testCheckMemberRoles(t, projectKey, username1, "Developer"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProject_missingAdminPrivileges(t *testing.T) {
+	name := "tftestprojects" + randSeq(10)
+
+	config := fmt.Sprintf(`
+		resource "project" "%[1]s" {
+			key = "%[2]s"
+			display_name = "%[1]s"
+		}
+	`, name, strings.ToLower(randSeq(6)))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(".*admin_privileges is required unless source_project_key is set.*"),
+			},
+		},
+	})
+}
+
+func TestCreateProject_sourceProjectKey(t *testing.T) {
+	fake, meta := newFakeJFrogMeta(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"user1", "user2"} {
+		if _, err := meta.Client.R().SetBody(map[string]string{}).Put("/artifactory/api/security/users/" + name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	template := Project{
+		Key:             "template",
+		DisplayName:     "Template",
+		AdminPrivileges: AdminPrivileges{ManageMembers: true, IndexResources: true},
+	}
+	if _, err := meta.Client.R().SetBody(template).Post(projectsUrl); err != nil {
+		t.Fatal(err)
+	}
+	if err := addRole(ctx, "template", Role{Name: "template role", Type: customRoleType, Environments: []string{"DEV"}, Actions: []string{"READ_REPOSITORY"}}, meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateMember(ctx, "template", usersMembershipType, Member{Name: "user1", Roles: []string{"template role"}}, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	r := projectResource()
+	data := r.TestResourceData()
+	data.Set("key", "test")
+	data.Set("display_name", "Test")
+	data.Set("source_project_key", "template")
+	data.Set("member", []interface{}{
+		map[string]interface{}{"name": "user2", "roles": []interface{}{"Developer"}},
+	})
+
+	if diags := r.CreateContext(ctx, data, meta); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	privileges := data.Get("admin_privileges").(*schema.Set).List()
+	if len(privileges) != 1 || privileges[0].(map[string]interface{})["manage_members"] != true {
+		t.Errorf("expected the admin privileges of the template, got %v", privileges)
+	}
+	if members := membersFromSet(data.Get("member").(*schema.Set)); len(members) != 1 || members[0].Name != "user2" {
+		t.Errorf("expected only the configured member in state, got %v", members)
+	}
+	if names := templateNames(data, "template_members"); len(names) != 1 || names[0] != "user1" {
+		t.Errorf("expected user1 in template_members, got %v", names)
+	}
+	if names := templateNames(data, "template_roles"); len(names) != 1 || names[0] != "template role" {
+		t.Errorf("expected template role in template_roles, got %v", names)
+	}
+	if roles := data.Get("role").(*schema.Set).Len(); roles != 0 {
+		t.Errorf("expected no role in state, got %d", roles)
+	}
+
+	// the copied member and role are left untouched by updates
+	if diags := r.UpdateContext(ctx, data, meta); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	project := fake.projects["test"]
+	if _, ok := project.members[usersMembershipType]["user1"]; !ok {
+		t.Error("expected the copied member to be kept")
+	}
+	if _, ok := project.roles["template role"]; !ok {
+		t.Error("expected the copied role to be kept")
+	}
+}
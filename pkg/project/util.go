@@ -1,8 +1,11 @@
 package project
 
 import (
+	"fmt"
 	"math"
+	"math/big"
 	"regexp"
+	"strings"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/jfrog/terraform-provider-shared/util"
@@ -24,6 +27,126 @@ func GibibytesToBytes(bytes int) int64 {
 	return int64(bytes) * int64(math.Pow(1024, 3))
 }
 
+const unlimitedStorage = "unlimited"
+
+// maxStorageBytes is the largest quota accepted by the API, i.e. maxStorageInGibibytes GiB
+const maxStorageBytes = maxStorageInGibibytes * 1024 * 1024 * 1024
+
+// storageUnits are the multipliers of the storage units, largest first: decimal (e.g. GB = 1000^3) and
+// binary (e.g. GiB = 1024^3)
+var storageUnits = []struct {
+	Name       string
+	Multiplier int64
+}{
+	{"PiB", 1 << 50},
+	{"PB", 1e15},
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+var storageRegex = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s*([a-zA-Z]+)\s*$`)
+
+// ParseStorage converts a storage size with a unit (e.g. `500GB`, `1.5TiB`) into bytes. `unlimited` (or -1)
+// is -1. Units are case-insensitive. The size is multiplied exactly (without floating point errors) and
+// rounded to the nearest byte, halves rounded up: `1.0005KB` is 1001 bytes, `1.0004KB` is 1000 bytes.
+// The result must be between 1 byte and maxStorageBytes.
+func ParseStorage(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, unlimitedStorage) || value == "-1" {
+		return -1, nil
+	}
+
+	matches := storageRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid storage size %q, expected a number followed by a unit (e.g. 500GB, 1.5TiB) or %q", value, unlimitedStorage)
+	}
+
+	var multiplier int64
+	for _, unit := range storageUnits {
+		if strings.EqualFold(unit.Name, matches[2]) {
+			multiplier = unit.Multiplier
+			break
+		}
+	}
+	if multiplier == 0 {
+		return 0, fmt.Errorf("invalid storage unit %q in %q, expected one of B, KB, MB, GB, TB, PB, KiB, MiB, GiB, TiB, PiB", matches[2], value)
+	}
+
+	size, ok := new(big.Rat).SetString(matches[1])
+	if !ok {
+		return 0, fmt.Errorf("invalid storage size %q", value)
+	}
+
+	// round half up: floor(size * multiplier + 1/2)
+	bytes := size.Mul(size, new(big.Rat).SetInt64(multiplier))
+	bytes.Add(bytes, big.NewRat(1, 2))
+	rounded := new(big.Int).Quo(bytes.Num(), bytes.Denom())
+
+	if rounded.Sign() < 1 {
+		return 0, fmt.Errorf("storage size %q must be at least 1 byte", value)
+	}
+	if !rounded.IsInt64() || rounded.Int64() > maxStorageBytes {
+		return 0, fmt.Errorf("storage size %q must be at most %dGiB", value, maxStorageInGibibytes)
+	}
+
+	return rounded.Int64(), nil
+}
+
+// FormatStorage converts bytes into the storage size with the largest unit the bytes are a whole multiple of,
+// e.g. 500000000000 is `500GB` and 1649267441664 is `1536GiB`, so ParseStorage returns the same bytes.
+func FormatStorage(bytes int64) string {
+	if bytes <= -1 {
+		return unlimitedStorage
+	}
+
+	for _, unit := range storageUnits {
+		if bytes != 0 && bytes%unit.Multiplier == 0 {
+			return fmt.Sprintf("%d%s", bytes/unit.Multiplier, unit.Name)
+		}
+	}
+
+	return fmt.Sprintf("%dB", bytes)
+}
+
+// storageEqual returns true if both storage sizes are the same number of bytes, e.g. `0.5TB` and `500GB`
+func storageEqual(a, b string) bool {
+	aBytes, err := ParseStorage(a)
+	if err != nil {
+		return false
+	}
+	bBytes, err := ParseStorage(b)
+	if err != nil {
+		return false
+	}
+
+	return aBytes == bBytes
+}
+
+// hasSameElements returns true if both slices contain the same strings, regardless of order and duplicates
+func hasSameElements(a, b []string) bool {
+	aSet := make(map[string]struct{}, len(a))
+	for _, v := range a {
+		aSet[v] = struct{}{}
+	}
+
+	bSet := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		if _, ok := aSet[v]; !ok {
+			return false
+		}
+		bSet[v] = struct{}{}
+	}
+
+	return len(aSet) == len(bSet)
+}
+
 type Equatable interface {
 	util.Identifiable
 	Equals(other Equatable) bool
@@ -5,12 +5,17 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-shared/client"
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
@@ -22,6 +27,35 @@ func testAccProviders() map[string]func() (*schema.Provider, error) {
 	}
 }
 
+// testAccProtoV5ProviderFactories serves both SDKv2 and framework providers, required for ephemeral resources
+func testAccProtoV5ProviderFactories() map[string]func() (tfprotov5.ProviderServer, error) {
+	return map[string]func() (tfprotov5.ProviderServer, error){
+		"project": func() (tfprotov5.ProviderServer, error) {
+			muxServer, err := tf5muxserver.NewMuxServer(context.Background(), MuxedProviders()...)
+			if err != nil {
+				return nil, err
+			}
+			return muxServer.ProviderServer(), nil
+		},
+	}
+}
+
+// newTestMeta serves the handler with a test server, closed at the end of the test, and returns the provider
+// metadata of a client for it
+func newTestMeta(t *testing.T, handler http.Handler) ProviderMetadata {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ProviderMetadata{ProvderMetadata: util.ProvderMetadata{Client: restyClient}}
+}
+
 type CheckFun func(id string, request *resty.Request) (*resty.Response, error)
 
 func verifyDeleted(id string, check CheckFun) func(*terraform.State) error {
@@ -34,7 +68,7 @@ func verifyDeleted(id string, check CheckFun) func(*terraform.State) error {
 		}
 		provider, _ := testAccProviders()["project"]()
 		provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))
-		client := provider.Meta().(util.ProvderMetadata).Client
+		client := provider.Meta().(ProviderMetadata).Client
 		resp, err := check(rs.Primary.ID, client.R())
 		if err != nil {
 			if resp != nil {
@@ -158,3 +192,256 @@ func deleteTestRepo(t *testing.T, name string) {
 		t.Fatal(err)
 	}
 }
+
+func TestParseStorage(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected int64
+	}{
+		{"unlimited", -1},
+		{"Unlimited", -1},
+		{"-1", -1},
+		{"1B", 1},
+		{"500GB", 500_000_000_000},
+		{"500 gb", 500_000_000_000},
+		{"0.5TB", 500_000_000_000},
+		{"1.5TiB", 1_649_267_441_664},
+		{"10GiB", 10_737_418_240},
+		{"1.1TB", 1_100_000_000_000},
+		{"0.1KB", 100},
+		// rounded to the nearest byte, halves rounded up
+		{"1.0004KB", 1000},
+		{"1.0005KB", 1001},
+		{"0.5B", 1},
+		{"1.4B", 1},
+		{"0.001KiB", 1},
+		{"8589934591GiB", maxStorageBytes},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			bytes, err := ParseStorage(tc.value)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if bytes != tc.expected {
+				t.Errorf("expected %d bytes, got %d", tc.expected, bytes)
+			}
+		})
+	}
+}
+
+func TestParseStorage_invalid(t *testing.T) {
+	testCases := map[string]string{
+		"":              "invalid storage size",
+		"500":           "invalid storage size",
+		"GB":            "invalid storage size",
+		"-5GB":          "invalid storage size",
+		"1,5GB":         "invalid storage size",
+		"500GiBs":       "invalid storage unit",
+		"500Gb ":        "",
+		"0GB":           "must be at least 1 byte",
+		"0.4B":          "must be at least 1 byte",
+		"8589934592GiB": "must be at most 8589934591GiB",
+		"10PiB":         "",
+		"9300PB":        "must be at most 8589934591GiB",
+	}
+
+	for value, expected := range testCases {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseStorage(value)
+			if expected == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Errorf("expected error containing %q, got %v", expected, err)
+			}
+		})
+	}
+}
+
+func TestFormatStorage(t *testing.T) {
+	testCases := []struct {
+		bytes    int64
+		expected string
+	}{
+		{-1, "unlimited"},
+		{1, "1B"},
+		{1000, "1KB"},
+		{1024, "1KiB"},
+		{1001, "1001B"},
+		{500_000_000_000, "500GB"},
+		{465 * 1024 * 1024 * 1024, "465GiB"},
+		{1_649_267_441_664, "1536GiB"},
+		{1 << 50, "1PiB"},
+		{maxStorageBytes, "8589934591GiB"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			formatted := FormatStorage(tc.bytes)
+			if formatted != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, formatted)
+			}
+
+			// exact round trip
+			bytes, err := ParseStorage(formatted)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if bytes != tc.bytes {
+				t.Errorf("expected %d bytes after round trip, got %d", tc.bytes, bytes)
+			}
+		})
+	}
+}
+
+func TestStorageEqual(t *testing.T) {
+	if !storageEqual("0.5TB", "500GB") {
+		t.Error("expected 0.5TB and 500GB to be equal")
+	}
+	if storageEqual("500GB", "500GiB") {
+		t.Error("expected 500GB and 500GiB to differ")
+	}
+	if storageEqual("500GB", "") {
+		t.Error("expected an invalid size not to be equal")
+	}
+}
+
+// sliceSet is the previous nested loops implementation of Set, used as reference in tests and benchmarks
+type sliceSet[T Equatable] []T
+
+func (s sliceSet[T]) Contains(b T) bool {
+	for _, a := range s {
+		if a.Equals(b) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s sliceSet[T]) Intersection(other sliceSet[T]) sliceSet[T] {
+	intersection := make(sliceSet[T], 0)
+	for _, item := range s {
+		if other.Contains(item) {
+			intersection = append(intersection, item)
+		}
+	}
+	return intersection
+}
+
+func (s sliceSet[T]) Difference(other sliceSet[T]) sliceSet[T] {
+	diff := make(sliceSet[T], 0)
+	for _, item := range s {
+		if !other.Contains(item) {
+			diff = append(diff, item)
+		}
+	}
+	return diff
+}
+
+func randomRepoKeys(r *rand.Rand, n, max int) []RepoKey {
+	seen := map[int]bool{}
+	repoKeys := []RepoKey{}
+	for len(repoKeys) < n {
+		i := r.Intn(max)
+		if !seen[i] {
+			seen[i] = true
+			repoKeys = append(repoKeys, RepoKey(fmt.Sprintf("repo-%d", i)))
+		}
+	}
+	return repoKeys
+}
+
+func repoKeysString(repoKeys []RepoKey) string {
+	keys := []string{}
+	for _, key := range repoKeys {
+		keys = append(keys, string(key))
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestSet_sameAsSliceSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		a := randomRepoKeys(r, r.Intn(50), 100)
+		b := randomRepoKeys(r, r.Intn(50), 100)
+
+		set, other := SetFromSlice(a), SetFromSlice(b)
+		reference, referenceOther := sliceSet[RepoKey](a), sliceSet[RepoKey](b)
+
+		if got, expected := repoKeysString(set.Intersection(other).Items()), repoKeysString(reference.Intersection(referenceOther)); got != expected {
+			t.Fatalf("Intersection(%v, %v): expected %s, got %s", a, b, expected, got)
+		}
+		if got, expected := repoKeysString(set.Difference(other).Items()), repoKeysString(reference.Difference(referenceOther)); got != expected {
+			t.Fatalf("Difference(%v, %v): expected %s, got %s", a, b, expected, got)
+		}
+		for _, key := range b {
+			if set.Contains(key) != reference.Contains(key) {
+				t.Fatalf("Contains(%v, %s): expected %t", a, key, reference.Contains(key))
+			}
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	a := SetFromSlice([]RepoKey{"repo-1", "repo-2", "repo-3", "repo-2"})
+	b := SetFromSlice([]RepoKey{"repo-4", "repo-3", "repo-2"})
+
+	testCases := []struct {
+		name     string
+		set      Set[RepoKey]
+		expected string
+	}{
+		{"duplicates are ignored", a, "repo-1,repo-2,repo-3"},
+		{"intersection", a.Intersection(b), "repo-2,repo-3"},
+		{"difference", a.Difference(b), "repo-1"},
+		{"union", a.Union(b), "repo-1,repo-2,repo-3,repo-4"},
+		{"symmetric difference", a.SymmetricDifference(b), "repo-1,repo-4"},
+		{"empty", Set[RepoKey]{}.Union(SetFromSlice[RepoKey](nil)), ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := repoKeysString(tc.set.Items()); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+
+	var set Set[RepoKey]
+	if !set.Add("repo-1") || set.Add("repo-1") || set.Len() != 1 {
+		t.Errorf("expected a single item to be added to the zero value Set, got %v", set)
+	}
+	if fmt.Sprintf("%+v", set) != "[repo-1]" {
+		t.Errorf("expected items to be logged, got %+v", set)
+	}
+}
+
+func BenchmarkSetDifference(b *testing.B) {
+	for _, size := range []int{100, 1000, 10000} {
+		r := rand.New(rand.NewSource(1))
+		project := randomRepoKeys(r, size, size*2)
+		terraform := randomRepoKeys(r, size, size*2)
+
+		b.Run(fmt.Sprintf("map/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				projectSet, terraformSet := SetFromSlice(project), SetFromSlice(terraform)
+				terraformSet.Difference(projectSet)
+				projectSet.Difference(terraformSet)
+			}
+		})
+
+		b.Run(fmt.Sprintf("slice/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				projectSet, terraformSet := sliceSet[RepoKey](project), sliceSet[RepoKey](terraform)
+				terraformSet.Difference(projectSet)
+				projectSet.Difference(terraformSet)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package project
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/agext/levenshtein"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
 )
 
 func maxLength(length int) func(i interface{}, k string) ([]string, []error) {
@@ -15,6 +18,19 @@ func maxLength(length int) func(i interface{}, k string) ([]string, []error) {
 	}
 }
 
+func storageSize(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := ParseStorage(v); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
 func int64Between(min, max int64) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warnings []string, errors []error) {
 		v1, ok := i.(int)
@@ -32,3 +48,48 @@ func int64Between(min, max int64) schema.SchemaValidateFunc {
 		return warnings, errors
 	}
 }
+
+// stringInSliceWithSuggestion is like validation.StringInSlice, and suggests the closest valid value for typos
+func stringInSliceWithSuggestion(valid []string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return warnings, errors
+		}
+
+		if slices.Contains(valid, v) {
+			return warnings, errors
+		}
+
+		if suggestion := closestMatch(v, valid); suggestion != "" {
+			errors = append(errors, fmt.Errorf("expected %s to be one of %v, got %s. Did you mean %s?", k, valid, v, suggestion))
+		} else {
+			errors = append(errors, fmt.Errorf("expected %s to be one of %v, got %s", k, valid, v))
+		}
+
+		return warnings, errors
+	}
+}
+
+// closestMatch returns the candidate closest to value, ignoring case, or an empty string when none is
+// close enough to be a likely typo
+func closestMatch(value string, candidates []string) string {
+	match := ""
+	minDistance := len(value)/3 + 1
+
+	for _, candidate := range candidates {
+		distance := levenshtein.Distance(strings.ToUpper(value), strings.ToUpper(candidate), nil)
+		// Prefix matches catch truncated values, e.g. READ_REPO for READ_REPOSITORY
+		if distance > 0 && strings.HasPrefix(strings.ToUpper(candidate), strings.ToUpper(value)) && len(value) >= 4 {
+			distance = 1
+		}
+
+		if distance < minDistance {
+			match = candidate
+			minDistance = distance
+		}
+	}
+
+	return match
+}
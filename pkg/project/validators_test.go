@@ -0,0 +1,49 @@
+package project
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClosestMatch(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "READ_REPO", expected: "READ_REPOSITORY"},
+		{value: "read_repository", expected: "READ_REPOSITORY"},
+		{value: "DEPLOY_CACH_REPOSITORY", expected: "DEPLOY_CACHE_REPOSITORY"},
+		{value: "MANAGE_MEMBER", expected: "MANAGE_MEMBERS"},
+		{value: "dev", expected: "DEV"},
+		{value: "PRD", expected: "PROD"},
+		{value: "SOMETHING_ELSE", expected: ""},
+	}
+
+	candidates := append(validRoleActions, validRoleEnvironments...)
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			if match := closestMatch(tc.value, candidates); match != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, match)
+			}
+		})
+	}
+}
+
+func TestStringInSliceWithSuggestion(t *testing.T) {
+	validate := stringInSliceWithSuggestion(validRoleActions)
+
+	if _, errs := validate("READ_REPOSITORY", "actions"); len(errs) != 0 {
+		t.Errorf("expected no error, got %v", errs)
+	}
+
+	_, errs := validate("READ_REPO", "actions")
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "Did you mean READ_REPOSITORY?") {
+		t.Errorf("expected error with suggestion, got %v", errs)
+	}
+
+	_, errs = validate("SOMETHING_ELSE", "actions")
+	if len(errs) != 1 || strings.Contains(errs[0].Error(), "Did you mean") {
+		t.Errorf("expected error without suggestion, got %v", errs)
+	}
+}